@@ -24,9 +24,21 @@ import (
 var staticFiles embed.FS
 
 var (
-	version   = "0.1.3"
-	port      = flag.String("port", "8080", "Port to run the server on")
-	noBrowser = flag.Bool("no-browser", false, "Don't open browser on start")
+	version            = "0.1.3"
+	port               = flag.String("port", "8080", "Port to run the server on")
+	noBrowser          = flag.Bool("no-browser", false, "Don't open browser on start")
+	portForwardHistory = flag.Int("port-forward-history", 20, "Number of closed port forwards to remember")
+	kubeconfig         = flag.String("kubeconfig", "", "Path to kubeconfig file (overrides KUBECONFIG)")
+	enableResources    = flag.String("enable-resources", "", "Comma-separated resource types and features to enable (default: all)")
+	disableResources   = flag.String("disable-resources", "", "Comma-separated resource types and features to disable, e.g. secrets,exec")
+	listTimeout        = flag.Int64("list-timeout", 30, "Seconds the API server may spend on a list call (0 to disable)")
+	maxResponseSize    = flag.Int64("max-response-size", 50, "Largest API response in MB before returning 413 (0 to disable)")
+	recordExec         = flag.Bool("record-exec", false, "Record exec session transcripts (input and output)")
+	execTranscriptDir  = flag.String("exec-transcript-dir", "", "Directory to write exec transcripts to (default: keep recent sessions in memory)")
+
+	configReloadInterval = flag.Int64("config-reload-interval", 0, "Seconds between checks for kubeconfig changes when listing contexts (0 to disable)")
+	requestTimeout       = flag.Int64("request-timeout", 0, "Seconds any single API call may take, excluding watches and log streams (0 to disable)")
+	scanContexts         = flag.Bool("scan-contexts", false, "Probe every context's reachability in the background at startup")
 )
 
 func main() {
@@ -51,11 +63,22 @@ func main() {
 	app.Logger().Infof("Starting KubeUI on http://localhost:%s", availablePort)
 
 	// Initialize Kubernetes client manager
-	k8sManager, err := service.NewK8sManager()
+	k8sManager, err := service.NewK8sManager(*kubeconfig)
 	if err != nil {
 		app.Logger().Errorf("Failed to initialize K8s manager: %v", err)
 		return
 	}
+	// Pick up contexts added by other tools without a restart
+	k8sManager.SetConfigReloadInterval(time.Duration(*configReloadInterval) * time.Second)
+	// A hung API call fails instead of blocking its handler forever
+	k8sManager.SetRequestTimeout(time.Duration(*requestTimeout) * time.Second)
+	// Warm the reachability cache so the cluster switcher shows health straight away
+	if *scanContexts {
+		go func() {
+			probed, reachable := k8sManager.ScanReachability()
+			app.Logger().Infof("Context scan: %d of %d probed contexts reachable", reachable, probed)
+		}()
+	}
 
 	// Initialize static file server
 	staticServer, err := handler.NewStaticFileServer(staticFiles, "dist")
@@ -64,26 +87,88 @@ func main() {
 		return
 	}
 
+	// Bound list calls so a degraded API server returns an error instead of hanging
+	handler.SetListTimeout(*listTimeout)
+
+	// Initialize resource filter for enabled/disabled resource types and features
+	resourceFilter := handler.NewResourceFilter(*enableResources, *disableResources)
+
 	// Initialize SSE handler early for middleware
 	sseHandler := handler.NewSSEHandler(k8sManager)
 
-	// Initialize exec handler for WebSocket
-	execHandler := handler.NewExecHandler(k8sManager)
+	// Initialize exec handler for WebSocket, recording transcripts if enabled
+	var execRecorder *handler.ExecRecorder
+	if *recordExec {
+		execRecorder, err = handler.NewExecRecorder(*execTranscriptDir)
+		if err != nil {
+			app.Logger().Errorf("Failed to initialize exec recorder: %v", err)
+			return
+		}
+	}
+	execHandler := handler.NewExecHandler(k8sManager, execRecorder)
+
+	// Initialize file handler for container file transfers
+	fileHandler := handler.NewFileHandler(k8sManager)
+
+	// Initialize log stream handler for multi-pod log tailing and log archives
+	logStreamHandler := handler.NewLogStreamHandler(k8sManager)
+
+	// Initialize pod watch handler for live pod detail pages
+	podWatchHandler := handler.NewPodWatchHandler(k8sManager)
+
+	// Initialize batch handler for multi-select actions
+	batchHandler := handler.NewBatchHandler(k8sManager, resourceFilter)
+
+	// Initialize rollout watch handler for live deployment rollout progress
+	rolloutWatchHandler := handler.NewRolloutWatchHandler(k8sManager)
+
+	// Initialize history handler for recently viewed resources
+	historyHandler := handler.NewHistoryHandler(k8sManager)
+
+	// Initialize proxy handler for in-cluster HTTP endpoints
+	proxyHandler := handler.NewProxyHandler(k8sManager)
+
+	// Add resource filter middleware first so disabled types never reach a handler
+	app.UseMiddleware(resourceFilter.Middleware)
 
 	// Add exec middleware for WebSocket terminal
 	app.UseMiddleware(execHandler.Middleware)
 
+	// Add file transfer middleware for container downloads and uploads
+	app.UseMiddleware(fileHandler.Middleware)
+
+	// Add log stream middleware for pod and selector-based log tailing and deployment log archives
+	app.UseMiddleware(logStreamHandler.Middleware)
+
+	// Add pod watch middleware for single-pod status streaming
+	app.UseMiddleware(podWatchHandler.Middleware)
+
+	// Add rollout watch middleware for deployment rollout streaming
+	app.UseMiddleware(rolloutWatchHandler.Middleware)
+
+	// Add batch middleware for streamed batch progress
+	app.UseMiddleware(batchHandler.Middleware)
+
+	// Add history middleware to record resource detail views
+	app.UseMiddleware(historyHandler.Middleware)
+
+	// Add proxy middleware for service and pod HTTP endpoints
+	app.UseMiddleware(proxyHandler.Middleware)
+
 	// Add SSE middleware for streaming
 	app.UseMiddleware(sseHandler.SSEMiddleware)
 
+	// Add response size guard for the regular API handlers; streams are served above
+	app.UseMiddleware(handler.NewResponseSizeGuard(*maxResponseSize << 20).Middleware)
+
 	// Add static file middleware (serves frontend)
 	app.UseMiddleware(staticServer.Middleware)
 
 	// Initialize handlers
 	clusterHandler := handler.NewClusterHandler(k8sManager)
 	namespaceHandler := handler.NewNamespaceHandler(k8sManager)
-	podHandler := handler.NewPodHandler(k8sManager)
-	deploymentHandler := handler.NewDeploymentHandler(k8sManager)
+	podHandler := handler.NewPodHandler(k8sManager, resourceFilter)
+	deploymentHandler := handler.NewDeploymentHandler(k8sManager, resourceFilter, execRecorder)
 	serviceHandler := handler.NewServiceHandler(k8sManager)
 	configMapHandler := handler.NewConfigMapHandler(k8sManager)
 	secretHandler := handler.NewSecretHandler(k8sManager)
@@ -94,27 +179,49 @@ func main() {
 	nodeHandler := handler.NewNodeHandler(k8sManager)
 	workloadHandler := handler.NewWorkloadHandler(k8sManager)
 	networkHandler := handler.NewNetworkHandler(k8sManager)
+	gatewayHandler := handler.NewGatewayHandler(k8sManager)
 	hpaHandler := handler.NewHPAHandler(k8sManager)
 	eventHandler := handler.NewEventHandler(k8sManager)
 	rbacHandler := handler.NewRBACHandler(k8sManager)
 	quotaHandler := handler.NewQuotaHandler(k8sManager)
-	searchHandler := handler.NewSearchHandler(k8sManager)
-	portForwardHandler := handler.NewPortForwardHandler(k8sManager)
+	searchHandler := handler.NewSearchHandler(k8sManager, resourceFilter)
+	recentChangesHandler := handler.NewRecentChangesHandler(k8sManager, resourceFilter)
+	orphanHandler := handler.NewOrphanHandler(k8sManager, resourceFilter)
+	portForwardHandler := handler.NewPortForwardHandler(k8sManager, *portForwardHistory)
+	admissionHandler := handler.NewAdmissionHandler(k8sManager)
+	schedulingHandler := handler.NewSchedulingHandler(k8sManager)
+	resourceHandler := handler.NewResourceHandler(k8sManager)
+	treeHandler := handler.NewTreeHandler(k8sManager)
+	coordinationHandler := handler.NewCoordinationHandler(k8sManager)
 
 	// Cluster routes
 	app.GET("/api/clusters", clusterHandler.List)
+	app.GET("/api/clusters/detailed", clusterHandler.ListDetailed)
 	app.GET("/api/clusters/current", clusterHandler.Current)
 	app.POST("/api/clusters/switch", clusterHandler.Switch)
 
 	// Namespace routes
 	app.GET("/api/namespaces", namespaceHandler.List)
+	app.GET("/api/namespaces/scope", namespaceHandler.Scope)
+	app.PUT("/api/namespaces/scope", namespaceHandler.SelectNamespace)
+	app.GET("/api/namespaces/{name}/ports", namespaceHandler.Ports)
+	app.GET("/api/namespaces/{name}/pod-security", namespaceHandler.PodSecurity)
+	app.GET("/api/namespaces/{name}/metrics", namespaceHandler.Metrics)
+	app.POST("/api/namespaces/{name}/scale-down", namespaceHandler.ScaleDown)
 
 	// Pod routes
 	app.GET("/api/pods", podHandler.List)
+	app.GET("/api/pods/crashlooping", podHandler.ListCrashLooping)
+	app.GET("/api/pods/oomkilled", podHandler.ListOOMKilled)
 	app.GET("/api/pods/{namespace}/{name}", podHandler.Get)
 	app.GET("/api/pods/{namespace}/{name}/logs", podHandler.Logs)
 	app.GET("/api/pods/{namespace}/{name}/events", podHandler.Events)
+	app.GET("/api/pods/{namespace}/{name}/status", podHandler.Status)
+	app.GET("/api/pods/{namespace}/{name}/restarts", podHandler.Restarts)
+	app.POST("/api/pods/{namespace}/{name}/debug", podHandler.Debug)
 	app.DELETE("/api/pods/{namespace}/{name}", podHandler.Delete)
+	app.GET("/api/pods/{namespace}/{name}/fs", fileHandler.List)
+	app.GET("/api/pods/{namespace}/{name}/netpol", networkHandler.PodNetworkPolicies)
 
 	// Port forward routes
 	app.GET("/api/portforwards", portForwardHandler.List)
@@ -122,28 +229,49 @@ func main() {
 	app.POST("/api/pods/{namespace}/{name}/portforward", portForwardHandler.Start)
 	app.DELETE("/api/pods/{namespace}/{name}/portforward", portForwardHandler.Stop)
 
+	// One-shot command execution, without a WebSocket
+	app.POST("/api/pods/{namespace}/{name}/exec-once", execHandler.ExecOnce)
+
+	// Exec session transcript routes (with --record-exec)
+	app.GET("/api/exec/sessions", execHandler.ListSessions)
+	app.GET("/api/exec/sessions/{id}/transcript", execHandler.Transcript)
+
 	// Deployment routes
 	app.GET("/api/deployments", deploymentHandler.List)
 	app.GET("/api/deployments/{namespace}/{name}", deploymentHandler.Get)
+	app.POST("/api/deployments/{namespace}", deploymentHandler.Create)
 	app.GET("/api/deployments/{namespace}/{name}/events", deploymentHandler.Events)
+	app.GET("/api/deployments/{namespace}/{name}/health", deploymentHandler.Health)
+	app.GET("/api/deployments/{namespace}/{name}/pending-change", deploymentHandler.PendingChangeDiff)
+	app.GET("/api/deployments/{namespace}/{name}/recommendations", deploymentHandler.Recommendations)
+	app.GET("/api/deployments/{namespace}/{name}/config-drift", deploymentHandler.ConfigDrift)
+	app.GET("/api/deployments/{namespace}/{name}/runtime-env", deploymentHandler.RuntimeEnv)
+	app.GET("/api/deployments/{namespace}/{name}/rollout", deploymentHandler.RolloutStatus)
 	app.PATCH("/api/deployments/{namespace}/{name}/scale", deploymentHandler.Scale)
+	app.PATCH("/api/deployments/{namespace}/{name}/image", deploymentHandler.SetImage)
 	app.POST("/api/deployments/{namespace}/{name}/restart", deploymentHandler.Restart)
+	app.GET("/api/deployments/{namespace}/{name}/history", deploymentHandler.History)
+	app.POST("/api/deployments/{namespace}/{name}/rollback", deploymentHandler.Rollback)
+	app.PATCH("/api/deployments/{namespace}/{name}/template-metadata", deploymentHandler.PatchTemplateMetadata)
 	app.DELETE("/api/deployments/{namespace}/{name}", deploymentHandler.Delete)
 
 	// Service routes
 	app.GET("/api/services", serviceHandler.List)
 	app.GET("/api/services/{namespace}/{name}", serviceHandler.Get)
 	app.GET("/api/services/{namespace}/{name}/events", serviceHandler.Events)
+	app.POST("/api/services/{namespace}/{name}/probe", serviceHandler.Probe)
 	app.DELETE("/api/services/{namespace}/{name}", serviceHandler.Delete)
 
 	// ConfigMap routes
 	app.GET("/api/configmaps", configMapHandler.List)
 	app.GET("/api/configmaps/{namespace}/{name}", configMapHandler.Get)
 	app.GET("/api/configmaps/{namespace}/{name}/events", configMapHandler.Events)
+	app.GET("/api/configmaps/{namespace}/{name}/related-versions", configMapHandler.RelatedVersions)
 	app.DELETE("/api/configmaps/{namespace}/{name}", configMapHandler.Delete)
 
 	// Secret routes
 	app.GET("/api/secrets", secretHandler.List)
+	app.GET("/api/secrets/certs", secretHandler.ExpiringCerts)
 	app.GET("/api/secrets/{namespace}/{name}", secretHandler.Get)
 	app.GET("/api/secrets/{namespace}/{name}/events", secretHandler.Events)
 	app.DELETE("/api/secrets/{namespace}/{name}", secretHandler.Delete)
@@ -156,19 +284,32 @@ func main() {
 	app.GET("/api/cronjobs/{namespace}/{name}", jobHandler.GetCronJob)
 	app.GET("/api/cronjobs/{namespace}/{name}/events", jobHandler.CronJobEvents)
 	app.GET("/api/cronjobs/{namespace}/{name}/jobs", jobHandler.CronJobJobs)
+	app.POST("/api/cronjobs/{namespace}/{name}/trigger", jobHandler.TriggerCronJob)
 	app.DELETE("/api/jobs/{namespace}/{name}", jobHandler.DeleteJob)
 	app.DELETE("/api/cronjobs/{namespace}/{name}", jobHandler.DeleteCronJob)
 
 	// Storage routes
 	app.GET("/api/pvs", storageHandler.ListPVs)
 	app.GET("/api/pvcs", storageHandler.ListPVCs)
+	app.GET("/api/pvcs/{namespace}/{name}/diagnose", storageHandler.DiagnosePVC)
 
 	// YAML routes
+	// Field owner routes come first so the cluster-scoped one isn't taken by the namespaced YAML route
+	app.GET("/api/yaml/{type}/{namespace}/{name}/fieldowners", yamlHandler.FieldOwners)
+	app.GET("/api/yaml/{type}/{name}/fieldowners", yamlHandler.FieldOwnersClusterScoped)
 	app.GET("/api/yaml/{type}/{namespace}/{name}", yamlHandler.Get)
 	app.GET("/api/yaml/{type}/{name}", yamlHandler.GetClusterScoped)
 	app.PUT("/api/yaml/{type}/{namespace}/{name}", yamlHandler.Update)
 	app.PUT("/api/yaml/{type}/{name}", yamlHandler.UpdateClusterScoped)
 
+	// Patch routes (JSON Patch, merge patch, or strategic merge patch)
+	app.PATCH("/api/patch/{type}/{namespace}/{name}", resourceHandler.Patch)
+	app.PATCH("/api/patch/{type}/{name}", resourceHandler.PatchClusterScoped)
+
+	// Finalizer removal routes (escape hatch for resources stuck terminating)
+	app.DELETE("/api/finalizers/{type}/{namespace}/{name}", resourceHandler.RemoveFinalizers)
+	app.DELETE("/api/finalizers/{type}/{name}", resourceHandler.RemoveFinalizersClusterScoped)
+
 	// CRD routes
 	app.GET("/api/crds", crdHandler.ListCRDs)
 	app.GET("/api/crds/{group}/{version}/{resource}", crdHandler.ListCRInstances)
@@ -181,6 +322,7 @@ func main() {
 	app.GET("/api/daemonsets", workloadHandler.ListDaemonSets)
 	app.GET("/api/daemonsets/{namespace}/{name}", workloadHandler.GetDaemonSet)
 	app.GET("/api/daemonsets/{namespace}/{name}/events", workloadHandler.DaemonSetEvents)
+	app.GET("/api/daemonsets/{namespace}/{name}/coverage", workloadHandler.DaemonSetCoverage)
 	app.GET("/api/statefulsets", workloadHandler.ListStatefulSets)
 	app.GET("/api/statefulsets/{namespace}/{name}", workloadHandler.GetStatefulSet)
 	app.GET("/api/statefulsets/{namespace}/{name}/events", workloadHandler.StatefulSetEvents)
@@ -191,6 +333,9 @@ func main() {
 	app.DELETE("/api/statefulsets/{namespace}/{name}", workloadHandler.DeleteStatefulSet)
 	app.DELETE("/api/replicasets/{namespace}/{name}", workloadHandler.DeleteReplicaSet)
 
+	// Workloads by container image
+	app.GET("/api/workloads/by-image", workloadHandler.ListByImage)
+
 	// Network routes (Ingresses, Endpoints, NetworkPolicies)
 	app.GET("/api/ingresses", networkHandler.ListIngresses)
 	app.GET("/api/endpoints", networkHandler.ListEndpoints)
@@ -198,28 +343,70 @@ func main() {
 	app.DELETE("/api/ingresses/{namespace}/{name}", networkHandler.DeleteIngress)
 	app.DELETE("/api/networkpolicies/{namespace}/{name}", networkHandler.DeleteNetworkPolicy)
 
+	// Gateway API routes (empty when the CRDs aren't installed)
+	app.GET("/api/gateways", gatewayHandler.ListGateways)
+	app.GET("/api/gateways/{namespace}/{name}", gatewayHandler.GetGateway)
+	app.GET("/api/httproutes", gatewayHandler.ListHTTPRoutes)
+	app.GET("/api/httproutes/{namespace}/{name}", gatewayHandler.GetHTTPRoute)
+
 	// HPA routes
 	app.GET("/api/hpas", hpaHandler.List)
+	app.GET("/api/hpas/activity", hpaHandler.Activity)
 	app.GET("/api/hpas/{namespace}/{name}", hpaHandler.Get)
 	app.GET("/api/hpas/{namespace}/{name}/events", hpaHandler.Events)
+	app.POST("/api/hpas", hpaHandler.Create)
+	app.DELETE("/api/hpas/{namespace}/{name}", hpaHandler.Delete)
 
 	// Event routes
 	app.GET("/api/events", eventHandler.List)
 	app.GET("/api/events/warnings", eventHandler.ListWarnings)
+	app.GET("/api/events/by-object", eventHandler.ListByObject)
 
 	// Storage Class routes
 	app.GET("/api/storageclasses", storageHandler.ListStorageClasses)
 
 	// RBAC routes
 	app.GET("/api/serviceaccounts", rbacHandler.ListServiceAccounts)
+	app.GET("/api/serviceaccounts/{namespace}/{name}/permissions", rbacHandler.ServiceAccountPermissions)
+	app.POST("/api/serviceaccounts/{namespace}/{name}/can-i", rbacHandler.ServiceAccountCanI)
 
 	// Quota routes
 	app.GET("/api/resourcequotas", quotaHandler.ListResourceQuotas)
 	app.GET("/api/limitranges", quotaHandler.ListLimitRanges)
 
+	// Admission webhook routes
+	app.GET("/api/mutatingwebhooks", admissionHandler.ListMutatingWebhooks)
+	app.GET("/api/validatingwebhooks", admissionHandler.ListValidatingWebhooks)
+	app.GET("/api/validatingadmissionpolicies", admissionHandler.ListValidatingAdmissionPolicies)
+
+	// Scheduling routes
+	app.GET("/api/priorityclasses", schedulingHandler.ListPriorityClasses)
+	app.GET("/api/runtimeclasses", schedulingHandler.ListRuntimeClasses)
+
+	// History route
+	app.GET("/api/history", historyHandler.List)
+
+	// Lease routes (leader election)
+	app.GET("/api/leases", coordinationHandler.ListLeases)
+
+	// Ownership tree route
+	app.GET("/api/tree/{namespace}/{kind}/{name}", treeHandler.Get)
+
+	// Image inventory route
+	app.GET("/api/images", podHandler.ListImages)
+
+	// Batch route for multi-select delete/restart; ?stream=true is served by the batch middleware
+	app.POST("/api/batch", batchHandler.Run)
+
 	// Search route
 	app.GET("/api/search", searchHandler.Search)
 
+	// Activity feed route for resources and events changed within ?since
+	app.GET("/api/recent-changes", recentChangesHandler.List)
+
+	// Cleanup route for unowned ReplicaSets, Pods, Jobs and ConfigMaps
+	app.GET("/api/orphans", orphanHandler.List)
+
 	// Version check route
 	app.GET("/api/version", func(ctx *gofr.Context) (interface{}, error) {
 		return getVersionInfo(), nil
@@ -0,0 +1,320 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+type AdmissionHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewAdmissionHandler(k8s *service.K8sManager) *AdmissionHandler {
+	return &AdmissionHandler{k8s: k8s}
+}
+
+type WebhookConfigurationInfo struct {
+	Name     string        `json:"name"`
+	Webhooks []WebhookInfo `json:"webhooks"`
+	Age      string        `json:"age"`
+}
+
+type WebhookInfo struct {
+	Name               string        `json:"name"`
+	FailurePolicy      string        `json:"failurePolicy"`
+	SideEffects        string        `json:"sideEffects,omitempty"`
+	TimeoutSeconds     int32         `json:"timeoutSeconds,omitempty"`
+	ReinvocationPolicy string        `json:"reinvocationPolicy,omitempty"`
+	Target             string        `json:"target"` // "service:namespace/name:port/path" or "url:..."
+	NamespaceSelector  string        `json:"namespaceSelector,omitempty"`
+	Rules              []WebhookRule `json:"rules,omitempty"`
+}
+
+type WebhookRule struct {
+	Operations  []string `json:"operations"`
+	APIGroups   []string `json:"apiGroups"`
+	APIVersions []string `json:"apiVersions"`
+	Resources   []string `json:"resources"`
+	Scope       string   `json:"scope,omitempty"`
+}
+
+// ListMutatingWebhooks returns all MutatingWebhookConfigurations in the cluster
+func (h *AdmissionHandler) ListMutatingWebhooks(ctx *gofr.Context) (interface{}, error) {
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []WebhookConfigurationInfo
+	for _, cfg := range configs.Items {
+		var webhooks []WebhookInfo
+		for _, wh := range cfg.Webhooks {
+			info := webhookToInfo(wh.Name, wh.ClientConfig, wh.Rules, wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds, wh.NamespaceSelector)
+			if wh.ReinvocationPolicy != nil {
+				info.ReinvocationPolicy = string(*wh.ReinvocationPolicy)
+			}
+			webhooks = append(webhooks, info)
+		}
+
+		result = append(result, WebhookConfigurationInfo{
+			Name:     cfg.Name,
+			Webhooks: webhooks,
+			Age:      formatAge(cfg.CreationTimestamp.Time),
+		})
+	}
+
+	return result, nil
+}
+
+// ListValidatingWebhooks returns all ValidatingWebhookConfigurations in the cluster
+func (h *AdmissionHandler) ListValidatingWebhooks(ctx *gofr.Context) (interface{}, error) {
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []WebhookConfigurationInfo
+	for _, cfg := range configs.Items {
+		var webhooks []WebhookInfo
+		for _, wh := range cfg.Webhooks {
+			webhooks = append(webhooks, webhookToInfo(wh.Name, wh.ClientConfig, wh.Rules, wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds, wh.NamespaceSelector))
+		}
+
+		result = append(result, WebhookConfigurationInfo{
+			Name:     cfg.Name,
+			Webhooks: webhooks,
+			Age:      formatAge(cfg.CreationTimestamp.Time),
+		})
+	}
+
+	return result, nil
+}
+
+// webhookToInfo converts the fields shared by mutating and validating webhooks
+func webhookToInfo(
+	name string,
+	clientConfig admissionregistrationv1.WebhookClientConfig,
+	rules []admissionregistrationv1.RuleWithOperations,
+	failurePolicy *admissionregistrationv1.FailurePolicyType,
+	sideEffects *admissionregistrationv1.SideEffectClass,
+	timeoutSeconds *int32,
+	namespaceSelector *metav1.LabelSelector,
+) WebhookInfo {
+	info := WebhookInfo{
+		Name: name,
+		// API server defaults failurePolicy to Fail for v1 webhooks
+		FailurePolicy: string(admissionregistrationv1.Fail),
+	}
+
+	if failurePolicy != nil {
+		info.FailurePolicy = string(*failurePolicy)
+	}
+	if sideEffects != nil {
+		info.SideEffects = string(*sideEffects)
+	}
+	if timeoutSeconds != nil {
+		info.TimeoutSeconds = *timeoutSeconds
+	}
+
+	// Target service or URL
+	if clientConfig.Service != nil {
+		svc := clientConfig.Service
+		port := int32(443)
+		if svc.Port != nil {
+			port = *svc.Port
+		}
+		path := ""
+		if svc.Path != nil {
+			path = *svc.Path
+		}
+		info.Target = fmt.Sprintf("service:%s/%s:%d%s", svc.Namespace, svc.Name, port, path)
+	} else if clientConfig.URL != nil {
+		info.Target = fmt.Sprintf("url:%s", *clientConfig.URL)
+	}
+
+	if namespaceSelector != nil {
+		info.NamespaceSelector = metav1.FormatLabelSelector(namespaceSelector)
+	}
+
+	for _, r := range rules {
+		info.Rules = append(info.Rules, ruleToInfo(r))
+	}
+
+	return info
+}
+
+func ruleToInfo(r admissionregistrationv1.RuleWithOperations) WebhookRule {
+	var ops []string
+	for _, op := range r.Operations {
+		ops = append(ops, string(op))
+	}
+
+	rule := WebhookRule{
+		Operations:  ops,
+		APIGroups:   r.APIGroups,
+		APIVersions: r.APIVersions,
+		Resources:   r.Resources,
+	}
+	if r.Scope != nil {
+		rule.Scope = string(*r.Scope)
+	}
+	return rule
+}
+
+type ValidatingAdmissionPolicyInfo struct {
+	Name             string                                 `json:"name"`
+	FailurePolicy    string                                 `json:"failurePolicy"`
+	ParamKind        string                                 `json:"paramKind,omitempty"` // "apiVersion/Kind"
+	MatchConstraints *PolicyMatchInfo                       `json:"matchConstraints,omitempty"`
+	MatchConditions  []PolicyExpression                     `json:"matchConditions,omitempty"`
+	Validations      []PolicyValidation                     `json:"validations"`
+	Bindings         []ValidatingAdmissionPolicyBindingInfo `json:"bindings"`
+	Age              string                                 `json:"age"`
+}
+
+type PolicyMatchInfo struct {
+	NamespaceSelector string        `json:"namespaceSelector,omitempty"`
+	ObjectSelector    string        `json:"objectSelector,omitempty"`
+	ResourceRules     []WebhookRule `json:"resourceRules,omitempty"`
+	ExcludeRules      []WebhookRule `json:"excludeResourceRules,omitempty"`
+}
+
+type PolicyExpression struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
+
+type PolicyValidation struct {
+	Expression        string `json:"expression"`
+	Message           string `json:"message,omitempty"`
+	MessageExpression string `json:"messageExpression,omitempty"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+type ValidatingAdmissionPolicyBindingInfo struct {
+	Name              string           `json:"name"`
+	ValidationActions []string         `json:"validationActions"`
+	ParamRef          string           `json:"paramRef,omitempty"` // "namespace/name" or "selector:..."
+	MatchResources    *PolicyMatchInfo `json:"matchResources,omitempty"`
+}
+
+// ListValidatingAdmissionPolicies returns CEL-based ValidatingAdmissionPolicies with
+// their bindings. Clusters older than 1.30 without the v1 API get an empty list.
+func (h *AdmissionHandler) ListValidatingAdmissionPolicies(ctx *gofr.Context) (interface{}, error) {
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := client.AdmissionregistrationV1().ValidatingAdmissionPolicies().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if apierrors.IsNotFound(err) {
+		return []ValidatingAdmissionPolicyInfo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bindings, err := client.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	bindingsByPolicy := make(map[string][]ValidatingAdmissionPolicyBindingInfo)
+	for _, b := range bindings.Items {
+		info := ValidatingAdmissionPolicyBindingInfo{
+			Name:              b.Name,
+			ValidationActions: []string{},
+			MatchResources:    matchResourcesToInfo(b.Spec.MatchResources),
+		}
+		for _, action := range b.Spec.ValidationActions {
+			info.ValidationActions = append(info.ValidationActions, string(action))
+		}
+		if ref := b.Spec.ParamRef; ref != nil {
+			if ref.Name != "" {
+				info.ParamRef = fmt.Sprintf("%s/%s", ref.Namespace, ref.Name)
+			} else if ref.Selector != nil {
+				info.ParamRef = "selector:" + metav1.FormatLabelSelector(ref.Selector)
+			}
+		}
+		bindingsByPolicy[b.Spec.PolicyName] = append(bindingsByPolicy[b.Spec.PolicyName], info)
+	}
+
+	result := []ValidatingAdmissionPolicyInfo{}
+	for _, p := range policies.Items {
+		info := ValidatingAdmissionPolicyInfo{
+			Name: p.Name,
+			// API server defaults failurePolicy to Fail
+			FailurePolicy:    string(admissionregistrationv1.Fail),
+			MatchConstraints: matchResourcesToInfo(p.Spec.MatchConstraints),
+			Validations:      []PolicyValidation{},
+			Bindings:         bindingsByPolicy[p.Name],
+			Age:              formatAge(p.CreationTimestamp.Time),
+		}
+		if info.Bindings == nil {
+			info.Bindings = []ValidatingAdmissionPolicyBindingInfo{}
+		}
+
+		if p.Spec.FailurePolicy != nil {
+			info.FailurePolicy = string(*p.Spec.FailurePolicy)
+		}
+		if p.Spec.ParamKind != nil {
+			info.ParamKind = fmt.Sprintf("%s/%s", p.Spec.ParamKind.APIVersion, p.Spec.ParamKind.Kind)
+		}
+		for _, mc := range p.Spec.MatchConditions {
+			info.MatchConditions = append(info.MatchConditions, PolicyExpression{Name: mc.Name, Expression: mc.Expression})
+		}
+		for _, v := range p.Spec.Validations {
+			validation := PolicyValidation{
+				Expression:        v.Expression,
+				Message:           v.Message,
+				MessageExpression: v.MessageExpression,
+			}
+			if v.Reason != nil {
+				validation.Reason = string(*v.Reason)
+			}
+			info.Validations = append(info.Validations, validation)
+		}
+
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+func matchResourcesToInfo(m *admissionregistrationv1.MatchResources) *PolicyMatchInfo {
+	if m == nil {
+		return nil
+	}
+
+	info := &PolicyMatchInfo{}
+	if m.NamespaceSelector != nil {
+		info.NamespaceSelector = metav1.FormatLabelSelector(m.NamespaceSelector)
+	}
+	if m.ObjectSelector != nil {
+		info.ObjectSelector = metav1.FormatLabelSelector(m.ObjectSelector)
+	}
+	for _, r := range m.ResourceRules {
+		info.ResourceRules = append(info.ResourceRules, ruleToInfo(r.RuleWithOperations))
+	}
+	for _, r := range m.ExcludeResourceRules {
+		info.ExcludeRules = append(info.ExcludeRules, ruleToInfo(r.RuleWithOperations))
+	}
+	return info
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+const (
+	// batchConcurrency bounds how many operations of one batch run at once
+	batchConcurrency = 5
+	// maxBatchOperations caps the size of a single batch
+	maxBatchOperations = 200
+)
+
+// restartableTypes are the resource types the batch "restart" action supports
+var restartableTypes = map[string]bool{
+	"deployments":  true,
+	"statefulsets": true,
+	"daemonsets":   true,
+}
+
+// BatchHandler runs multi-select actions as one request with per-operation results
+type BatchHandler struct {
+	k8s    *service.K8sManager
+	filter *ResourceFilter
+}
+
+func NewBatchHandler(k8s *service.K8sManager, filter *ResourceFilter) *BatchHandler {
+	return &BatchHandler{k8s: k8s, filter: filter}
+}
+
+// BatchOperation is one action on one resource. Action is "delete" or "restart".
+type BatchOperation struct {
+	Action    string `json:"action"`
+	Type      string `json:"type"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+type batchRequest struct {
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchResult is the outcome of one operation
+type BatchResult struct {
+	BatchOperation
+	Index   int    `json:"index"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BatchResponse reports every operation's result in request order
+type BatchResponse struct {
+	Total     int           `json:"total"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Results   []BatchResult `json:"results"`
+}
+
+// Run executes POST /api/batch and returns once every operation has finished
+func (h *BatchHandler) Run(ctx *gofr.Context) (interface{}, error) {
+	var req batchRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+	if err := validateBatch(req.Operations); err != nil {
+		return nil, err
+	}
+
+	return h.execute(req.Operations, nil), nil
+}
+
+// Middleware serves POST /api/batch?stream=true as an SSE stream with one
+// "progress" message per finished operation and a final "done" message
+func (h *BatchHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/batch" || r.URL.Query().Get("stream") != "true" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		h.HandleStream(w, r)
+	})
+}
+
+// HandleStream runs a batch and reports progress over SSE
+func (h *BatchHandler) HandleStream(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := validateBatch(req.Operations); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	// Operations keep running if the client goes away; only progress reporting stops
+	var mu sync.Mutex
+	response := h.execute(req.Operations, func(result BatchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Context().Err() == nil {
+			sendSSEMessage(w, flusher, SSEMessage{Type: "progress", Resource: "batch", Data: result})
+		}
+	})
+
+	if r.Context().Err() == nil {
+		sendSSEMessage(w, flusher, SSEMessage{Type: "done", Resource: "batch", Data: response})
+	}
+}
+
+func validateBatch(operations []BatchOperation) error {
+	if len(operations) == 0 {
+		return errors.New("operations are required")
+	}
+	if len(operations) > maxBatchOperations {
+		return fmt.Errorf("a batch may contain at most %d operations", maxBatchOperations)
+	}
+	return nil
+}
+
+// execute runs operations with bounded concurrency, calling onResult (if set) as each finishes
+func (h *BatchHandler) execute(operations []BatchOperation, onResult func(BatchResult)) BatchResponse {
+	results := make([]BatchResult, len(operations))
+	sem := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+
+	for i, op := range operations {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, op BatchOperation) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := BatchResult{BatchOperation: op, Index: i, Success: true}
+			if err := h.run(op); err != nil {
+				result.Success = false
+				result.Error = err.Error()
+			}
+			results[i] = result
+
+			if onResult != nil {
+				onResult(result)
+			}
+		}(i, op)
+	}
+	wg.Wait()
+
+	response := BatchResponse{Total: len(results), Results: results}
+	for _, result := range results {
+		if result.Success {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+	}
+	return response
+}
+
+// run performs a single operation through the dynamic client
+func (h *BatchHandler) run(op BatchOperation) error {
+	if op.Name == "" {
+		return errors.New("name is required")
+	}
+	if !h.filter.Enabled(op.Type) {
+		return fmt.Errorf("resource type %q is disabled", op.Type)
+	}
+
+	gvr, err := gvrFor(op.Type)
+	if err != nil {
+		return err
+	}
+
+	resource, err := resourceClient(h.k8s, gvr, op.Namespace)
+	if err != nil {
+		return err
+	}
+
+	switch op.Action {
+	case "delete":
+		// Background propagation also removes dependents, e.g. a Job's pods
+		propagationPolicy := metav1.DeletePropagationBackground
+		return resource.Delete(context.Background(), op.Name, metav1.DeleteOptions{
+			PropagationPolicy: &propagationPolicy,
+		})
+	case "restart":
+		if !restartableTypes[op.Type] {
+			return fmt.Errorf("restart is not supported for %s", op.Type)
+		}
+		// Same annotation kubectl rollout restart sets
+		patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"%s"}}}}}`,
+			time.Now().Format(time.RFC3339))
+		_, err := resource.Patch(context.Background(), op.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		return err
+	default:
+		return fmt.Errorf("unknown action %q", op.Action)
+	}
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxAccessChecks caps how many reviews one request may create
+const maxAccessChecks = 100
+
+// defaultAccessChecks are what workloads most often need from the API, used when a request lists none
+var defaultAccessChecks = []AccessCheck{
+	{Verb: "get", Resource: "pods"},
+	{Verb: "list", Resource: "pods"},
+	{Verb: "watch", Resource: "pods"},
+	{Verb: "get", Resource: "configmaps"},
+	{Verb: "list", Resource: "configmaps"},
+	{Verb: "get", Resource: "secrets"},
+	{Verb: "list", Resource: "services"},
+	{Verb: "list", Resource: "endpoints"},
+	{Verb: "create", Resource: "events"},
+	{Verb: "get", Group: "coordination.k8s.io", Resource: "leases"},
+	{Verb: "update", Group: "coordination.k8s.io", Resource: "leases"},
+	{Verb: "list", Group: "apps", Resource: "deployments"},
+}
+
+// AccessCheck is one verb on a resource, like the arguments to kubectl auth can-i
+type AccessCheck struct {
+	Verb        string `json:"verb"`
+	Group       string `json:"group,omitempty"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"` // Defaults to the service account's; "*" for all namespaces
+}
+
+// AccessCheckResult is the authorizer's answer for one check
+type AccessCheckResult struct {
+	AccessCheck
+	Allowed bool   `json:"allowed"`
+	Denied  bool   `json:"denied,omitempty"` // Explicitly denied, not just without a matching rule
+	Reason  string `json:"reason,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type canIRequest struct {
+	Checks []AccessCheck `json:"checks"`
+}
+
+// ServiceAccountCanI answers `kubectl auth can-i --as` for a service account:
+// each check becomes a SubjectAccessReview for the account's user and groups.
+// This needs permission to create subjectaccessreviews rather than to
+// impersonate, and no request is ever made as the account itself.
+func (h *RBACHandler) ServiceAccountCanI(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	var req canIRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+	checks := req.Checks
+	if len(checks) == 0 {
+		checks = defaultAccessChecks
+	}
+	if len(checks) > maxAccessChecks {
+		return nil, fmt.Errorf("at most %d checks are allowed per request", maxAccessChecks)
+	}
+	for _, check := range checks {
+		if check.Verb == "" || check.Resource == "" {
+			return nil, fmt.Errorf("verb and resource are required in every check")
+		}
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.CoreV1().ServiceAccounts(namespace).Get(context.Background(), name, metav1.GetOptions{}); err != nil {
+		return lookupError(err)
+	}
+
+	// The identity the API server gives the account's tokens
+	user := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+	groups := []string{"system:serviceaccounts", "system:serviceaccounts:" + namespace, "system:authenticated"}
+
+	result := make([]AccessCheckResult, 0, len(checks))
+	for _, check := range checks {
+		if check.Namespace == "" {
+			check.Namespace = namespace
+		}
+		reviewNamespace := check.Namespace
+		if reviewNamespace == "*" {
+			reviewNamespace = ""
+		}
+
+		review := &authv1.SubjectAccessReview{
+			Spec: authv1.SubjectAccessReviewSpec{
+				User:   user,
+				Groups: groups,
+				ResourceAttributes: &authv1.ResourceAttributes{
+					Namespace:   reviewNamespace,
+					Verb:        check.Verb,
+					Group:       check.Group,
+					Resource:    check.Resource,
+					Subresource: check.Subresource,
+					Name:        check.Name,
+				},
+			},
+		}
+
+		entry := AccessCheckResult{AccessCheck: check}
+		response, err := client.AuthorizationV1().SubjectAccessReviews().Create(context.Background(), review, metav1.CreateOptions{})
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Allowed = response.Status.Allowed
+			entry.Denied = response.Status.Denied
+			entry.Reason = response.Status.Reason
+			if response.Status.EvaluationError != "" {
+				entry.Error = response.Status.EvaluationError
+			}
+		}
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
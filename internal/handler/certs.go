@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"math"
+	"sort"
+	"time"
+)
+
+// CertificateInfo is the human-relevant part of an X.509 certificate
+type CertificateInfo struct {
+	Subject       string   `json:"subject"`
+	Issuer        string   `json:"issuer"`
+	DNSNames      []string `json:"dnsNames,omitempty"`
+	IPAddresses   []string `json:"ipAddresses,omitempty"`
+	NotBefore     string   `json:"notBefore"`
+	NotAfter      string   `json:"notAfter"`
+	DaysRemaining int      `json:"daysRemaining"` // Negative once expired
+	IsCA          bool     `json:"isCA"`
+}
+
+// parseCertificates parses every CERTIFICATE block in PEM data, leaf first as in tls.crt
+func parseCertificates(pemData []byte) ([]CertificateInfo, error) {
+	var result []CertificateInfo
+	for {
+		var block *pem.Block
+		block, pemData = pem.Decode(pemData)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, certificateToInfo(cert))
+	}
+	return result, nil
+}
+
+func certificateToInfo(cert *x509.Certificate) CertificateInfo {
+	var ips []string
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+
+	return CertificateInfo{
+		Subject:       cert.Subject.String(),
+		Issuer:        cert.Issuer.String(),
+		DNSNames:      cert.DNSNames,
+		IPAddresses:   ips,
+		NotBefore:     cert.NotBefore.Format(time.RFC3339),
+		NotAfter:      cert.NotAfter.Format(time.RFC3339),
+		DaysRemaining: int(math.Floor(time.Until(cert.NotAfter).Hours() / 24)),
+		IsCA:          cert.IsCA,
+	}
+}
+
+// parseDockerConfigRegistries returns the registries in a .dockerconfigjson or legacy .dockercfg payload
+func parseDockerConfigRegistries(data []byte, legacy bool) ([]string, error) {
+	var auths map[string]json.RawMessage
+	if legacy {
+		if err := json.Unmarshal(data, &auths); err != nil {
+			return nil, err
+		}
+	} else {
+		var config struct {
+			Auths map[string]json.RawMessage `json:"auths"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+		auths = config.Auths
+	}
+
+	registries := make([]string, 0, len(auths))
+	for registry := range auths {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	return registries, nil
+}
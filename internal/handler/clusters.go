@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"sort"
+	"sync"
+
 	"gofr.dev/pkg/gofr"
 
 	"github.com/opengittr/kubeui/internal/service"
@@ -47,3 +50,41 @@ func (h *ClusterHandler) Switch(ctx *gofr.Context) (interface{}, error) {
 		"namespace": h.k8s.GetDefaultNamespace(),
 	}, nil
 }
+
+// ClusterDetails is a context with its endpoint, auth method and reachability
+type ClusterDetails struct {
+	service.ContextDetails
+	// Reachability is nil when the context wasn't probed, see ReachabilityNote
+	Reachability     *service.Reachability `json:"reachability,omitempty"`
+	ReachabilityNote string                `json:"reachabilityNote,omitempty"`
+}
+
+// ListDetailed returns every context with its server, auth method and cached
+// reachability. Contexts using an exec credential plugin are only probed when
+// current, since running the plugin may prompt or open a browser.
+func (h *ClusterHandler) ListDetailed(ctx *gofr.Context) (interface{}, error) {
+	contexts := h.k8s.DescribeContexts()
+	result := make([]ClusterDetails, len(contexts))
+
+	var wg sync.WaitGroup
+	for i, details := range contexts {
+		result[i].ContextDetails = details
+		if details.AuthMethod == "exec" && !details.IsCurrent {
+			result[i].ReachabilityNote = "not probed: the exec credential plugin " + details.ExecCommand + " may prompt or open a browser"
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			reachability := h.k8s.CheckReachability(name)
+			result[i].Reachability = &reachability
+		}(i, details.Name)
+	}
+	wg.Wait()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checksumAnnotationPrefix marks pod template annotations holding a config checksum,
+// e.g. checksum/configmap-app-config: <configChecksum of the ConfigMap data>
+const checksumAnnotationPrefix = "checksum/"
+
+// ConfigDrift reports ConfigMaps and Secrets that changed after a deployment's pods started
+type ConfigDrift struct {
+	Name          string            `json:"name"`
+	Namespace     string            `json:"namespace"`
+	Drifted       bool              `json:"drifted"`
+	PossiblyStale bool              `json:"possiblyStale"`
+	References    []ConfigReference `json:"references"`
+}
+
+// ConfigReference is one ConfigMap or Secret used by the pod template
+type ConfigReference struct {
+	Kind               string   `json:"kind"`
+	Name               string   `json:"name"`
+	UsedAs             []string `json:"usedAs"` // env, envFrom, volume, subPath
+	Missing            bool     `json:"missing,omitempty"`
+	Checksum           string   `json:"checksum,omitempty"`           // configChecksum of the current data
+	ChecksumAnnotation string   `json:"checksumAnnotation,omitempty"` // The checksum/ template annotation naming this reference
+	AnnotationChecksum string   `json:"annotationChecksum,omitempty"` // Its value
+	LastUpdated        string   `json:"lastUpdated,omitempty"`
+	OldestPodStart     string   `json:"oldestPodStart,omitempty"`
+	Drifted            bool     `json:"drifted"`       // The checksum annotation no longer matches the data
+	PossiblyStale      bool     `json:"possiblyStale"` // Updated after the oldest pod started, with no checksum to confirm a data change
+	Reason             string   `json:"reason,omitempty"`
+}
+
+// ConfigDrift hashes the ConfigMaps and Secrets the deployment references and
+// compares them with the pod template's checksum/ annotations, flagging drift
+// when one no longer matches. References without an annotation can only be
+// judged by time: one updated after the oldest running pod started is reported
+// as possibly stale, since a metadata-only update moves the timestamp too.
+// Secrets are skipped when the secrets filter is off.
+func (h *DeploymentHandler) ConfigDrift(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+		LabelSelector: selector.String(),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	var oldestStart time.Time
+	for _, pod := range pods.Items {
+		if pod.Status.StartTime == nil || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if oldestStart.IsZero() || pod.Status.StartTime.Time.Before(oldestStart) {
+			oldestStart = pod.Status.StartTime.Time
+		}
+	}
+
+	result := ConfigDrift{
+		Name:       deployment.Name,
+		Namespace:  deployment.Namespace,
+		References: []ConfigReference{},
+	}
+
+	annotations := deployment.Spec.Template.Annotations
+	for _, ref := range templateConfigReferences(&deployment.Spec.Template.Spec) {
+		var (
+			meta metav1.ObjectMeta
+			data map[string][]byte
+		)
+		if ref.Kind == "ConfigMap" {
+			cm, err := client.CoreV1().ConfigMaps(namespace).Get(context.Background(), ref.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				ref.Missing = true
+				ref.Reason = "ConfigMap does not exist"
+				result.References = append(result.References, ref)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			meta = cm.ObjectMeta
+			data = make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+			for k, v := range cm.Data {
+				data[k] = []byte(v)
+			}
+			for k, v := range cm.BinaryData {
+				data[k] = v
+			}
+		} else {
+			if !h.filter.Enabled("secrets") {
+				ref.Reason = "Not checked: secrets are disabled"
+				result.References = append(result.References, ref)
+				continue
+			}
+			secret, err := client.CoreV1().Secrets(namespace).Get(context.Background(), ref.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				ref.Missing = true
+				ref.Reason = "Secret does not exist"
+				result.References = append(result.References, ref)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			meta = secret.ObjectMeta
+			data = secret.Data
+		}
+
+		ref.Checksum = configChecksum(data)
+		lastUpdated := lastUpdateTime(meta)
+		ref.LastUpdated = lastUpdated.Format(time.RFC3339)
+		if !oldestStart.IsZero() {
+			ref.OldestPodStart = oldestStart.Format(time.RFC3339)
+		}
+
+		if key, checksum, ok := checksumAnnotation(annotations, ref); ok {
+			ref.ChecksumAnnotation = key
+			ref.AnnotationChecksum = checksum
+			if checksum != ref.Checksum {
+				ref.Drifted = true
+				ref.Reason = key + " no longer matches the current data"
+			}
+		} else if !oldestStart.IsZero() && lastUpdated.After(oldestStart) {
+			ref.PossiblyStale = true
+			ref.Reason = "Updated after the oldest running pod started; without a checksum annotation this may be a metadata-only change"
+		}
+		if (ref.Drifted || ref.PossiblyStale) && onlyMountedAsVolume(ref.UsedAs) {
+			ref.Reason += "; mounted files are refreshed automatically but the process may not reload them"
+		}
+
+		result.Drifted = result.Drifted || ref.Drifted
+		result.PossiblyStale = result.PossiblyStale || ref.PossiblyStale
+		result.References = append(result.References, ref)
+	}
+
+	return result, nil
+}
+
+// templateConfigReferences collects the ConfigMaps and Secrets a pod spec uses, sorted by kind and name
+func templateConfigReferences(spec *corev1.PodSpec) []ConfigReference {
+	refs := make(map[string]*ConfigReference)
+	use := func(kind, name, usedAs string) {
+		key := kind + "/" + name
+		if refs[key] == nil {
+			refs[key] = &ConfigReference{Kind: kind, Name: name}
+		}
+		for _, u := range refs[key].UsedAs {
+			if u == usedAs {
+				return
+			}
+		}
+		refs[key].UsedAs = append(refs[key].UsedAs, usedAs)
+	}
+
+	// Volumes mounted with subPath never see updates, unlike whole-volume mounts
+	volumeKinds := make(map[string][]ConfigReference)
+	for _, v := range spec.Volumes {
+		switch {
+		case v.ConfigMap != nil:
+			volumeKinds[v.Name] = append(volumeKinds[v.Name], ConfigReference{Kind: "ConfigMap", Name: v.ConfigMap.Name})
+		case v.Secret != nil:
+			volumeKinds[v.Name] = append(volumeKinds[v.Name], ConfigReference{Kind: "Secret", Name: v.Secret.SecretName})
+		case v.Projected != nil:
+			for _, source := range v.Projected.Sources {
+				if source.ConfigMap != nil {
+					volumeKinds[v.Name] = append(volumeKinds[v.Name], ConfigReference{Kind: "ConfigMap", Name: source.ConfigMap.Name})
+				}
+				if source.Secret != nil {
+					volumeKinds[v.Name] = append(volumeKinds[v.Name], ConfigReference{Kind: "Secret", Name: source.Secret.Name})
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		for _, ef := range c.EnvFrom {
+			if ef.ConfigMapRef != nil {
+				use("ConfigMap", ef.ConfigMapRef.Name, "envFrom")
+			}
+			if ef.SecretRef != nil {
+				use("Secret", ef.SecretRef.Name, "envFrom")
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if e.ValueFrom.ConfigMapKeyRef != nil {
+				use("ConfigMap", e.ValueFrom.ConfigMapKeyRef.Name, "env")
+			}
+			if e.ValueFrom.SecretKeyRef != nil {
+				use("Secret", e.ValueFrom.SecretKeyRef.Name, "env")
+			}
+		}
+		for _, m := range c.VolumeMounts {
+			usedAs := "volume"
+			if m.SubPath != "" || m.SubPathExpr != "" {
+				usedAs = "subPath"
+			}
+			for _, ref := range volumeKinds[m.Name] {
+				use(ref.Kind, ref.Name, usedAs)
+			}
+		}
+	}
+
+	result := make([]ConfigReference, 0, len(refs))
+	for _, ref := range refs {
+		result = append(result, *ref)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// checksumAnnotation finds the checksum/ annotation for a reference, keyed as
+// checksum/<kind>-<name> (e.g. checksum/configmap-app-config) or checksum/<name>
+func checksumAnnotation(annotations map[string]string, ref ConfigReference) (key, checksum string, ok bool) {
+	for _, suffix := range []string{strings.ToLower(ref.Kind) + "-" + ref.Name, ref.Name} {
+		key = checksumAnnotationPrefix + suffix
+		if checksum, ok = annotations[key]; ok {
+			return key, checksum, true
+		}
+	}
+	return "", "", false
+}
+
+// configChecksum is the hex sha256 of the data's keys and values in key order,
+// each followed by a NUL byte
+func configChecksum(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, k := range keys {
+		hash.Write([]byte(k))
+		hash.Write([]byte{0})
+		hash.Write(data[k])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// lastUpdateTime is the latest managedFields timestamp, falling back to the creation time
+func lastUpdateTime(meta metav1.ObjectMeta) time.Time {
+	latest := meta.CreationTimestamp.Time
+	for _, entry := range meta.ManagedFields {
+		if entry.Time != nil && entry.Time.After(latest) {
+			latest = entry.Time.Time
+		}
+	}
+	return latest
+}
+
+func onlyMountedAsVolume(usedAs []string) bool {
+	for _, u := range usedAs {
+		if u != "volume" {
+			return false
+		}
+	}
+	return true
+}
@@ -30,14 +30,14 @@ type ConfigMapInfo struct {
 }
 
 func (h *ConfigMapHandler) List(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	cms, err := client.CoreV1().ConfigMaps(namespace).List(context.Background(), metav1.ListOptions{})
+	cms, err := client.CoreV1().ConfigMaps(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +71,7 @@ func (h *ConfigMapHandler) Get(ctx *gofr.Context) (interface{}, error) {
 
 	cm, err := client.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	keys := make([]string, 0, len(cm.Data))
@@ -107,9 +107,9 @@ func (h *ConfigMapHandler) Events(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=ConfigMap", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// kustomizeHashAlphabet is the character set of the 10-character suffix kustomize's configMapGenerator appends
+const kustomizeHashAlphabet = "bcdfghjkmnpqrstvwxz2456789"
+
+// ConfigMapVersions is a ConfigMap and its siblings generated from the same base name
+type ConfigMapVersions struct {
+	BaseName string             `json:"baseName"`
+	Versions []ConfigMapVersion `json:"versions"`
+}
+
+// ConfigMapVersion is one generated ConfigMap sharing the base name
+type ConfigMapVersion struct {
+	Name      string `json:"name"`
+	Suffix    string `json:"suffix,omitempty"` // Empty for a ConfigMap named exactly the base name
+	Keys      int    `json:"keys"`
+	Immutable bool   `json:"immutable"`
+	UsedBy    int    `json:"usedBy"`    // Pods referencing it through env, envFrom or volumes
+	Requested bool   `json:"requested"` // The ConfigMap named in the request
+	Newest    bool   `json:"newest"`
+	Created   string `json:"created"`
+	Age       string `json:"age"`
+}
+
+// RelatedVersions finds ConfigMaps generated from the same base name with a
+// content-hash suffix, e.g. app-config-7f9c2b4d6k and app-config-m8h5t2g9bc,
+// newest first. Versions no pod uses are usually stale leftovers of earlier
+// rollouts. A name without a recognisable suffix is treated as the base name.
+func (h *ConfigMapHandler) RelatedVersions(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{}); err != nil {
+		return lookupError(err)
+	}
+
+	configMaps, err := client.CoreV1().ConfigMaps(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	usedBy := make(map[string]int)
+	for _, pod := range pods.Items {
+		for _, ref := range templateConfigReferences(&pod.Spec) {
+			if ref.Kind == "ConfigMap" {
+				usedBy[ref.Name]++
+			}
+		}
+	}
+
+	base, _ := splitHashSuffix(name)
+	result := ConfigMapVersions{BaseName: base, Versions: []ConfigMapVersion{}}
+	created := make(map[string]time.Time)
+	for _, cm := range configMaps.Items {
+		cmBase, suffix := splitHashSuffix(cm.Name)
+		if cmBase != base {
+			continue
+		}
+		created[cm.Name] = cm.CreationTimestamp.Time
+		result.Versions = append(result.Versions, ConfigMapVersion{
+			Name:      cm.Name,
+			Suffix:    suffix,
+			Keys:      len(cm.Data) + len(cm.BinaryData),
+			Immutable: cm.Immutable != nil && *cm.Immutable,
+			UsedBy:    usedBy[cm.Name],
+			Requested: cm.Name == name,
+			Created:   cm.CreationTimestamp.Format(time.RFC3339),
+			Age:       formatAge(cm.CreationTimestamp.Time),
+		})
+	}
+
+	sort.Slice(result.Versions, func(i, j int) bool {
+		a, b := created[result.Versions[i].Name], created[result.Versions[j].Name]
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return result.Versions[i].Name < result.Versions[j].Name
+	})
+	if len(result.Versions) > 0 {
+		result.Versions[0].Newest = true
+	}
+
+	return result, nil
+}
+
+// splitHashSuffix splits a generated name into its base and hash suffix. The
+// suffix must be the last dash-separated part and look like a hash: kustomize's
+// 10-character encoding, or 5 or more lowercase letters and digits including
+// at least one digit, so names like app-config-nginx are left whole.
+func splitHashSuffix(name string) (base, suffix string) {
+	i := strings.LastIndex(name, "-")
+	if i <= 0 {
+		return name, ""
+	}
+	candidate := name[i+1:]
+	if len(candidate) < 5 {
+		return name, ""
+	}
+
+	hasDigit, kustomize := false, len(candidate) == 10
+	for _, r := range candidate {
+		switch {
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r >= 'a' && r <= 'z':
+		default:
+			return name, ""
+		}
+		if !strings.ContainsRune(kustomizeHashAlphabet, r) {
+			kustomize = false
+		}
+	}
+	if !hasDigit && !kustomize {
+		return name, ""
+	}
+	return name[:i], candidate
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+type CoordinationHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewCoordinationHandler(k8s *service.K8sManager) *CoordinationHandler {
+	return &CoordinationHandler{k8s: k8s}
+}
+
+type LeaseInfo struct {
+	Name                 string `json:"name"`
+	Namespace            string `json:"namespace"`
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int32  `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int32  `json:"leaseTransitions"`
+	// Expired is true when the holder hasn't renewed within the lease duration
+	Expired bool   `json:"expired"`
+	Age     string `json:"age"`
+}
+
+// ListLeases returns coordination.k8s.io Leases, optionally filtered by namespace
+func (h *CoordinationHandler) ListLeases(ctx *gofr.Context) (interface{}, error) {
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	leases, err := client.CoordinationV1().Leases(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []LeaseInfo
+	for _, lease := range leases.Items {
+		info := LeaseInfo{
+			Name:      lease.Name,
+			Namespace: lease.Namespace,
+			Age:       formatAge(lease.CreationTimestamp.Time),
+		}
+
+		if lease.Spec.HolderIdentity != nil {
+			info.HolderIdentity = *lease.Spec.HolderIdentity
+		}
+		if lease.Spec.LeaseDurationSeconds != nil {
+			info.LeaseDurationSeconds = *lease.Spec.LeaseDurationSeconds
+		}
+		if lease.Spec.AcquireTime != nil {
+			info.AcquireTime = lease.Spec.AcquireTime.Format(time.RFC3339)
+		}
+		if lease.Spec.RenewTime != nil {
+			info.RenewTime = lease.Spec.RenewTime.Format(time.RFC3339)
+			expiry := lease.Spec.RenewTime.Add(time.Duration(info.LeaseDurationSeconds) * time.Second)
+			info.Expired = time.Now().After(expiry)
+		}
+		if lease.Spec.LeaseTransitions != nil {
+			info.LeaseTransitions = *lease.Spec.LeaseTransitions
+		}
+
+		result = append(result, info)
+	}
+
+	return result, nil
+}
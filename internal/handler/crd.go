@@ -2,8 +2,10 @@ package handler
 
 import (
 	"context"
+	"fmt"
 
 	"gofr.dev/pkg/gofr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -53,7 +55,7 @@ func (h *CRDHandler) ListCRDs(ctx *gofr.Context) (interface{}, error) {
 		Resource: "customresourcedefinitions",
 	}
 
-	list, err := dynClient.Resource(crdGVR).List(context.Background(), metav1.ListOptions{})
+	list, err := dynClient.Resource(crdGVR).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +112,7 @@ func (h *CRDHandler) ListCRInstances(ctx *gofr.Context) (interface{}, error) {
 	group := ctx.PathParam("group")
 	version := ctx.PathParam("version")
 	resource := ctx.PathParam("resource")
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	config, err := h.k8s.GetConfig()
 	if err != nil {
@@ -130,9 +132,13 @@ func (h *CRDHandler) ListCRInstances(ctx *gofr.Context) (interface{}, error) {
 
 	var list *unstructured.UnstructuredList
 	if namespace != "" {
-		list, err = dynClient.Resource(gvr).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+		list, err = dynClient.Resource(gvr).Namespace(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	} else {
-		list, err = dynClient.Resource(gvr).List(context.Background(), metav1.ListOptions{})
+		list, err = dynClient.Resource(gvr).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		// Users without cluster-wide list may still list in individual namespaces
+		if apierrors.IsForbidden(err) {
+			list, err = h.listInAccessibleNamespaces(dynClient, gvr)
+		}
 	}
 
 	if err != nil {
@@ -151,6 +157,46 @@ func (h *CRDHandler) ListCRInstances(ctx *gofr.Context) (interface{}, error) {
 	return crs, nil
 }
 
+// listInAccessibleNamespaces aggregates CR instances across every namespace the
+// user may list them in. If namespaces themselves can't be listed, the
+// context's default namespace is tried.
+func (h *CRDHandler) listInAccessibleNamespaces(dynClient dynamic.Interface, gvr schema.GroupVersionResource) (*unstructured.UnstructuredList, error) {
+	namespaces := []string{h.k8s.GetDefaultNamespace()}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	if nsList, err := client.CoreV1().Namespaces().List(context.Background(), listOptions(metav1.ListOptions{})); err == nil {
+		namespaces = namespaces[:0]
+		for _, ns := range nsList.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	result := &unstructured.UnstructuredList{}
+	accessible := 0
+	for _, ns := range namespaces {
+		allowed, err := h.k8s.CheckAccess("list", gvr.Group, gvr.Resource, ns, "")
+		if err != nil || !allowed {
+			continue
+		}
+
+		list, err := dynClient.Resource(gvr).Namespace(ns).List(context.Background(), listOptions(metav1.ListOptions{}))
+		if err != nil {
+			continue
+		}
+		accessible++
+		result.Items = append(result.Items, list.Items...)
+	}
+
+	if accessible == 0 {
+		return nil, apierrors.NewForbidden(gvr.GroupResource(), "", fmt.Errorf("cannot list in any namespace"))
+	}
+
+	return result, nil
+}
+
 // GetCRInstance returns a specific Custom Resource instance
 func (h *CRDHandler) GetCRInstance(ctx *gofr.Context) (interface{}, error) {
 	group := ctx.PathParam("group")
@@ -183,7 +229,7 @@ func (h *CRDHandler) GetCRInstance(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	return obj.Object, nil
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// daemonSetAutoTolerations are the node taints the DaemonSet controller adds
+// tolerations for on every DaemonSet pod, so they never explain a missing pod
+var daemonSetAutoTolerations = map[string]bool{
+	corev1.TaintNodeNotReady:           true,
+	corev1.TaintNodeUnreachable:        true,
+	corev1.TaintNodeDiskPressure:       true,
+	corev1.TaintNodeMemoryPressure:     true,
+	corev1.TaintNodePIDPressure:        true,
+	corev1.TaintNodeUnschedulable:      true,
+	corev1.TaintNodeNetworkUnavailable: true, // Only for hostNetwork pods, see daemonSetTolerates
+}
+
+// DaemonSetCoverage lists every node and whether the DaemonSet runs a pod there
+type DaemonSetCoverage struct {
+	Name      string                  `json:"name"`
+	Namespace string                  `json:"namespace"`
+	Desired   int32                   `json:"desired"`
+	Covered   int                     `json:"covered"` // Nodes with a DaemonSet pod
+	Nodes     []DaemonSetNodeCoverage `json:"nodes"`
+}
+
+// DaemonSetNodeCoverage is one node's view of a DaemonSet
+type DaemonSetNodeCoverage struct {
+	Node      string   `json:"node"`
+	NodeReady bool     `json:"nodeReady"`
+	Pod       string   `json:"pod,omitempty"`
+	PodPhase  string   `json:"podPhase,omitempty"`
+	Eligible  bool     `json:"eligible"`          // Selector, affinity and taints allow a pod here
+	Reasons   []string `json:"reasons,omitempty"` // Likely causes when there's no pod or it can't run
+}
+
+// DaemonSetCoverage marks each node with the DaemonSet's pod on it, and for the
+// rest explains the likely gap: a nodeSelector or required node affinity that
+// doesn't match, a NoSchedule/NoExecute taint that isn't tolerated, or the node
+// not being Ready. The check mirrors the controller's predicates loosely; it
+// doesn't account for resources or host ports.
+func (h *WorkloadHandler) DaemonSetCoverage(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := client.AppsV1().DaemonSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return lookupError(err)
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+		LabelSelector: selector.String(),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	podsByNode := make(map[string]*corev1.Pod)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if owner := metav1.GetControllerOf(pod); pod.Spec.NodeName == "" || owner == nil || owner.UID != ds.UID {
+			continue
+		}
+		podsByNode[pod.Spec.NodeName] = pod
+	}
+
+	result := DaemonSetCoverage{
+		Name:      ds.Name,
+		Namespace: ds.Namespace,
+		Desired:   ds.Status.DesiredNumberScheduled,
+		Nodes:     []DaemonSetNodeCoverage{},
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		coverage := DaemonSetNodeCoverage{
+			Node:      node.Name,
+			NodeReady: nodeIsReady(node),
+		}
+
+		mismatches := daemonSetNodeMismatches(ds, node)
+		coverage.Eligible = len(mismatches) == 0
+
+		if pod, ok := podsByNode[node.Name]; ok {
+			result.Covered++
+			coverage.Pod = pod.Name
+			coverage.PodPhase = string(pod.Status.Phase)
+			if !coverage.NodeReady && pod.Status.Phase != corev1.PodRunning {
+				coverage.Reasons = append(coverage.Reasons, "node not Ready")
+			}
+		} else {
+			coverage.Reasons = mismatches
+			if !coverage.NodeReady {
+				coverage.Reasons = append(coverage.Reasons, "node not Ready")
+			}
+			if len(coverage.Reasons) == 0 {
+				coverage.Reasons = []string{"no pod yet; check the DaemonSet's events"}
+			}
+		}
+		result.Nodes = append(result.Nodes, coverage)
+	}
+
+	// Uncovered nodes first, then by name
+	sort.Slice(result.Nodes, func(i, j int) bool {
+		a, b := result.Nodes[i], result.Nodes[j]
+		if (a.Pod == "") != (b.Pod == "") {
+			return a.Pod == ""
+		}
+		return a.Node < b.Node
+	})
+
+	return result, nil
+}
+
+// nodeIsReady reports whether the node's Ready condition is True
+func nodeIsReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// daemonSetNodeMismatches returns why the DaemonSet's pod template can't land on the node
+func daemonSetNodeMismatches(ds *appsv1.DaemonSet, node *corev1.Node) []string {
+	spec := &ds.Spec.Template.Spec
+	var reasons []string
+
+	if spec.NodeName != "" && spec.NodeName != node.Name {
+		reasons = append(reasons, fmt.Sprintf("pinned to node %s", spec.NodeName))
+	}
+
+	keys := make([]string, 0, len(spec.NodeSelector))
+	for k := range spec.NodeSelector {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if v, ok := node.Labels[k]; !ok || v != spec.NodeSelector[k] {
+			reasons = append(reasons, fmt.Sprintf("nodeSelector %s=%s not matched", k, spec.NodeSelector[k]))
+		}
+	}
+
+	if affinity := spec.Affinity; affinity != nil && affinity.NodeAffinity != nil {
+		if required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			matched := false
+			for _, term := range required.NodeSelectorTerms {
+				if nodeSelectorTermMatches(term, node) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				reasons = append(reasons, "required node affinity not matched")
+			}
+		}
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		// PreferNoSchedule taints are only a preference
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule || daemonSetTolerates(spec, taint) {
+			continue
+		}
+		tolerated := false
+		for j := range spec.Tolerations {
+			if toleratesTaint(&spec.Tolerations[j], taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			reasons = append(reasons, fmt.Sprintf("taint %s not tolerated", taint.ToString()))
+		}
+	}
+
+	return reasons
+}
+
+// daemonSetTolerates reports whether the controller adds a toleration for the
+// taint itself. network-unavailable is only tolerated for hostNetwork pods.
+func daemonSetTolerates(spec *corev1.PodSpec, taint *corev1.Taint) bool {
+	if taint.Key == corev1.TaintNodeNetworkUnavailable {
+		return spec.HostNetwork
+	}
+	return daemonSetAutoTolerations[taint.Key]
+}
+
+// toleratesTaint follows Toleration.ToleratesTaint for the Equal and Exists operators
+func toleratesTaint(t *corev1.Toleration, taint *corev1.Taint) bool {
+	if t.Effect != "" && t.Effect != taint.Effect {
+		return false
+	}
+	if t.Key != "" && t.Key != taint.Key {
+		return false
+	}
+	switch t.Operator {
+	case "", corev1.TolerationOpEqual:
+		return t.Value == taint.Value
+	case corev1.TolerationOpExists:
+		return true
+	}
+	return false
+}
+
+// nodeSelectorTermMatches reports whether the node satisfies every requirement
+// of a node affinity term; an empty term matches nothing, as in the scheduler
+func nodeSelectorTermMatches(term corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	for _, req := range term.MatchExpressions {
+		value, exists := node.Labels[req.Key]
+		if !nodeSelectorRequirementMatches(req, value, exists) {
+			return false
+		}
+	}
+	for _, req := range term.MatchFields {
+		// metadata.name is the only supported field
+		if req.Key != "metadata.name" || !nodeSelectorRequirementMatches(req, node.Name, true) {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeSelectorRequirementMatches(req corev1.NodeSelectorRequirement, value string, exists bool) bool {
+	switch req.Operator {
+	case corev1.NodeSelectorOpIn:
+		return exists && slices.Contains(req.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !exists || !slices.Contains(req.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return exists
+	case corev1.NodeSelectorOpDoesNotExist:
+		return !exists
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if !exists || len(req.Values) != 1 {
+			return false
+		}
+		have, err1 := strconv.ParseInt(value, 10, 64)
+		want, err2 := strconv.ParseInt(req.Values[0], 10, 64)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		if req.Operator == corev1.NodeSelectorOpGt {
+			return have > want
+		}
+		return have < want
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultDebugImage has a shell and the usual tools, and runs sh by default
+	defaultDebugImage = "busybox:1.36"
+	// debugStartTimeout bounds the wait for the debug container to start, including the image pull
+	debugStartTimeout = 2 * time.Minute
+)
+
+type debugRequest struct {
+	Image  string `json:"image"`
+	Target string `json:"target"` // Container whose process namespace to share
+}
+
+// DebugContainer is a started ephemeral container, ready for the exec WebSocket
+type DebugContainer struct {
+	Name      string `json:"name"`
+	Pod       string `json:"pod"`
+	Namespace string `json:"namespace"`
+	Image     string `json:"image"`
+	Target    string `json:"target,omitempty"`
+}
+
+// Debug adds an ephemeral container to the pod, like kubectl debug, and waits
+// for it to run. With a target the container shares that container's process
+// namespace, so its processes and filesystem (via /proc/1/root) are visible
+// even in shell-less images. Ephemeral containers can't be removed; they stay
+// until the pod is deleted.
+func (h *PodHandler) Debug(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	var req debugRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+	if req.Image == "" {
+		req.Image = defaultDebugImage
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil, fmt.Errorf("pod %s is %s; debug containers can only be added to running pods", name, pod.Status.Phase)
+	}
+	if req.Target != "" {
+		found := false
+		for _, c := range pod.Spec.Containers {
+			if c.Name == req.Target {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("container %q not found in pod %s", req.Target, name)
+		}
+	}
+
+	// Same naming as kubectl debug
+	containerName := "debugger-" + utilrand.String(5)
+	updated := pod.DeepCopy()
+	updated.Spec.EphemeralContainers = append(updated.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     containerName,
+			Image:                    req.Image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: req.Target,
+	})
+
+	if _, err := client.CoreV1().Pods(namespace).UpdateEphemeralContainers(context.Background(), name, updated, metav1.UpdateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to add debug container: %w", err)
+	}
+
+	if err := waitForEphemeralContainer(ctx, client, namespace, name, containerName); err != nil {
+		return nil, err
+	}
+
+	return DebugContainer{
+		Name:      containerName,
+		Pod:       name,
+		Namespace: namespace,
+		Image:     req.Image,
+		Target:    req.Target,
+	}, nil
+}
+
+// waitForEphemeralContainer polls until the container runs, fails to start, or the timeout passes
+func waitForEphemeralContainer(ctx context.Context, client kubernetes.Interface, namespace, pod, container string) error {
+	ctx, cancel := context.WithTimeout(ctx, debugStartTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		p, err := client.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get pod: %w", err)
+		}
+		for _, cs := range p.Status.EphemeralContainerStatuses {
+			if cs.Name != container {
+				continue
+			}
+			switch {
+			case cs.State.Running != nil:
+				return nil
+			case cs.State.Terminated != nil:
+				return fmt.Errorf("debug container %s exited: %s", container, cs.State.Terminated.Reason)
+			case cs.State.Waiting != nil && imagePullFailures[cs.State.Waiting.Reason]:
+				return fmt.Errorf("debug container %s can't start: %s: %s", container, cs.State.Waiting.Reason, cs.State.Waiting.Message)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for debug container %s to start", container)
+		case <-ticker.C:
+		}
+	}
+}
+
+// imagePullFailures are waiting reasons that won't resolve by waiting longer
+var imagePullFailures = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// changeCauseAnnotation records why a revision was made, as shown by kubectl rollout history
+const changeCauseAnnotation = "kubernetes.io/change-cause"
+
+// DeploymentRevision is one ReplicaSet in a deployment's rollout history
+type DeploymentRevision struct {
+	Revision    int64    `json:"revision"`
+	ReplicaSet  string   `json:"replicaSet"`
+	Images      []string `json:"images"`
+	ChangeCause string   `json:"changeCause,omitempty"`
+	Replicas    int32    `json:"replicas"`
+	Current     bool     `json:"current"`
+	Created     string   `json:"created"`
+	Age         string   `json:"age"`
+}
+
+type rollbackRequest struct {
+	Revision int64 `json:"revision"` // 0 means the previous revision, as with kubectl rollout undo
+}
+
+// History lists the deployment's ReplicaSets by revision, newest first. Only
+// revisions still within revisionHistoryLimit can be listed or rolled back to.
+func (h *DeploymentHandler) History(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return lookupError(err)
+	}
+
+	replicaSets, err := deploymentReplicaSets(client, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	current := deployment.Annotations[revisionAnnotation]
+	result := []DeploymentRevision{}
+	for _, rs := range replicaSets {
+		revision, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		entry := DeploymentRevision{
+			Revision:    revision,
+			ReplicaSet:  rs.Name,
+			Images:      []string{},
+			ChangeCause: rs.Annotations[changeCauseAnnotation],
+			Replicas:    rs.Status.Replicas,
+			Current:     rs.Annotations[revisionAnnotation] == current,
+			Created:     rs.CreationTimestamp.Format(time.RFC3339),
+			Age:         formatAge(rs.CreationTimestamp.Time),
+		}
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			entry.Images = append(entry.Images, c.Image)
+		}
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+// Rollback restores the pod template of an earlier revision, like kubectl
+// rollout undo. The deployment controller then rolls out the old ReplicaSet
+// again under a new revision number.
+func (h *DeploymentHandler) Rollback(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	var req rollbackRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+	if req.Revision < 0 {
+		return nil, fmt.Errorf("revision must not be negative")
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return lookupError(err)
+	}
+	if deployment.Spec.Paused {
+		return nil, fmt.Errorf("deployment %s is paused; resume it before rolling back", name)
+	}
+
+	replicaSets, err := deploymentReplicaSets(client, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	current, _ := strconv.ParseInt(deployment.Annotations[revisionAnnotation], 10, 64)
+	var target *appsv1.ReplicaSet
+	for _, rs := range replicaSets {
+		revision, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		if req.Revision == 0 && revision < current {
+			// Newest first, so the first older revision is the previous one
+			target = rs
+			break
+		}
+		if req.Revision != 0 && revision == req.Revision {
+			target = rs
+			break
+		}
+	}
+	if target == nil {
+		if req.Revision == 0 {
+			return nil, fmt.Errorf("deployment %s has no previous revision", name)
+		}
+		return nil, fmt.Errorf("revision %d not found for deployment %s", req.Revision, name)
+	}
+	if target.Annotations[revisionAnnotation] == deployment.Annotations[revisionAnnotation] {
+		return nil, fmt.Errorf("deployment %s is already at revision %d", name, req.Revision)
+	}
+
+	// The controller adds pod-template-hash to the ReplicaSet's template labels
+	template := target.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	// Test the resourceVersion so a concurrent edit fails the patch instead of being overwritten
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": deployment.ResourceVersion},
+		{"op": "replace", "path": "/spec/template", "value": template},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.AppsV1().Deployments(namespace).Patch(context.Background(), name, types.JSONPatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"message":  fmt.Sprintf("Deployment %s rolled back to revision %s", name, target.Annotations[revisionAnnotation]),
+		"revision": target.Annotations[revisionAnnotation],
+	}, nil
+}
+
+// deploymentReplicaSets returns the ReplicaSets controlled by the deployment, newest revision first
+func deploymentReplicaSets(client kubernetes.Interface, deployment *appsv1.Deployment) ([]*appsv1.ReplicaSet, error) {
+	list, err := client.AppsV1().ReplicaSets(deployment.Namespace).List(context.Background(), listOptions(metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	var replicaSets []*appsv1.ReplicaSet
+	for i := range list.Items {
+		rs := &list.Items[i]
+		if owner := metav1.GetControllerOf(rs); owner == nil || owner.UID != deployment.UID {
+			continue
+		}
+		replicaSets = append(replicaSets, rs)
+	}
+
+	revisionOf := func(rs *appsv1.ReplicaSet) int64 {
+		revision, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		return revision
+	}
+	sort.Slice(replicaSets, func(i, j int) bool {
+		return revisionOf(replicaSets[i]) > revisionOf(replicaSets[j])
+	})
+	return replicaSets, nil
+}
@@ -2,12 +2,18 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"gofr.dev/pkg/gofr"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -16,11 +22,16 @@ import (
 )
 
 type DeploymentHandler struct {
-	k8s *service.K8sManager
+	k8s      *service.K8sManager
+	filter   *ResourceFilter
+	recorder *ExecRecorder // nil unless exec recording is enabled
 }
 
-func NewDeploymentHandler(k8s *service.K8sManager) *DeploymentHandler {
-	return &DeploymentHandler{k8s: k8s}
+// NewDeploymentHandler creates a deployment handler. Secret values referenced
+// by env are only resolved when the filter allows secrets, and the exec that
+// reads a pod's runtime environment is recorded when recorder is set.
+func NewDeploymentHandler(k8s *service.K8sManager, filter *ResourceFilter, recorder *ExecRecorder) *DeploymentHandler {
+	return &DeploymentHandler{k8s: k8s, filter: filter, recorder: recorder}
 }
 
 type DeploymentInfo struct {
@@ -40,6 +51,11 @@ type DeploymentInfo struct {
 	ContainerDetails  []DeploymentContainer     `json:"containerDetails,omitempty"`
 	Conditions        []DeploymentCondition     `json:"conditions,omitempty"`
 	RunningContainers []RunningContainer        `json:"runningContainers,omitempty"`
+	// Pod template's shutdown budget after preStop hooks and SIGTERM
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Pod template metadata, distinct from the deployment's own; changing it triggers a rollout
+	TemplateLabels      map[string]string `json:"templateLabels,omitempty"`
+	TemplateAnnotations map[string]string `json:"templateAnnotations,omitempty"`
 }
 
 // RunningContainer represents a container instance running in a pod
@@ -54,12 +70,16 @@ type RunningContainer struct {
 }
 
 type DeploymentContainer struct {
-	Name   string                    `json:"name"`
-	Image  string                    `json:"image"`
-	CPU    ResourceUsage             `json:"cpu"`
-	Memory ResourceUsage             `json:"memory"`
-	Ports  []DeploymentContainerPort `json:"ports,omitempty"`
-	Env    []EnvVar                  `json:"env,omitempty"`
+	Name      string                    `json:"name"`
+	Image     string                    `json:"image"`
+	CPU       ResourceUsage             `json:"cpu"`
+	Memory    ResourceUsage             `json:"memory"`
+	Ports     []DeploymentContainerPort `json:"ports,omitempty"`
+	Env       []EnvVar                  `json:"env,omitempty"`
+	Lifecycle *LifecycleHooks           `json:"lifecycle,omitempty"`
+
+	// Names declared more than once across env and envFrom
+	EnvConflicts []EnvConflict `json:"envConflicts,omitempty"`
 }
 
 // ResourceUsage is defined in pods.go
@@ -79,7 +99,7 @@ type DeploymentCondition struct {
 
 // List returns all deployments, optionally filtered by namespace
 func (h *DeploymentHandler) List(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 	if namespace == "" {
 		namespace = "" // empty means all namespaces
 	}
@@ -89,7 +109,7 @@ func (h *DeploymentHandler) List(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	deployments, err := client.AppsV1().Deployments(namespace).List(context.Background(), metav1.ListOptions{})
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +134,7 @@ func (h *DeploymentHandler) Get(ctx *gofr.Context) (interface{}, error) {
 
 	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	// Fetch running containers from pods belonging to this deployment
@@ -123,7 +143,13 @@ func (h *DeploymentHandler) Get(ctx *gofr.Context) (interface{}, error) {
 		runningContainers = h.fetchRunningContainers(namespace, deployment.Spec.Selector.MatchLabels)
 	}
 
-	return deploymentToInfoWithRunningContainers(deployment, runningContainers, client, namespace), nil
+	if sortBy := ctx.Param("sortBy"); sortBy != "" {
+		if err := sortRunningContainers(runningContainers, sortBy); err != nil {
+			return nil, err
+		}
+	}
+
+	return deploymentToInfoWithRunningContainers(deployment, runningContainers, client, namespace, h.filter), nil
 }
 
 // fetchRunningContainers gets all running container instances from pods matching the selector
@@ -141,9 +167,9 @@ func (h *DeploymentHandler) fetchRunningContainers(namespace string, selector ma
 	}
 
 	// List pods matching the selector
-	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		LabelSelector: labelSelector,
-	})
+	}))
 	if err != nil {
 		return nil
 	}
@@ -152,9 +178,9 @@ func (h *DeploymentHandler) fetchRunningContainers(namespace string, selector ma
 	metricsMap := make(map[string]map[string]ContainerResource) // podName -> containerName -> metrics
 	mc, err := h.k8s.GetMetricsClient()
 	if err == nil {
-		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), metav1.ListOptions{
+		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 			LabelSelector: labelSelector,
-		})
+		}))
 		if err == nil {
 			for _, pm := range podMetrics.Items {
 				if metricsMap[pm.Name] == nil {
@@ -220,6 +246,36 @@ func (h *DeploymentHandler) fetchRunningContainers(namespace string, selector ma
 	return result
 }
 
+// sortRunningContainers orders containers by their pod's total CPU or memory
+// usage, highest first, keeping each pod's containers together
+func sortRunningContainers(containers []RunningContainer, sortBy string) error {
+	usage := func(rc RunningContainer) int64 { return rc.CPU.Usage }
+	switch sortBy {
+	case "cpu":
+	case "memory":
+		usage = func(rc RunningContainer) int64 { return rc.Memory.Usage }
+	default:
+		return fmt.Errorf("invalid sortBy %q: must be cpu or memory", sortBy)
+	}
+
+	podTotals := make(map[string]int64)
+	for _, rc := range containers {
+		podTotals[rc.PodName] += usage(rc)
+	}
+
+	sort.SliceStable(containers, func(i, j int) bool {
+		a, b := containers[i], containers[j]
+		if podTotals[a.PodName] != podTotals[b.PodName] {
+			return podTotals[a.PodName] > podTotals[b.PodName]
+		}
+		if a.PodName != b.PodName {
+			return a.PodName < b.PodName
+		}
+		return usage(a) > usage(b)
+	})
+	return nil
+}
+
 type scaleRequest struct {
 	Replicas int32 `json:"replicas"`
 }
@@ -250,10 +306,39 @@ func (h *DeploymentHandler) Scale(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"message":  fmt.Sprintf("Deployment %s scaled to %d replicas", name, req.Replicas),
 		"replicas": req.Replicas,
-	}, nil
+	}
+
+	// An HPA will overwrite manually set replicas on its next sync
+	if hpa := findDeploymentHPA(client, namespace, name); hpa != nil {
+		minReplicas := int32(1)
+		if hpa.Spec.MinReplicas != nil {
+			minReplicas = *hpa.Spec.MinReplicas
+		}
+		result["hpa"] = hpa.Name
+		result["warning"] = fmt.Sprintf("HorizontalPodAutoscaler %s manages this deployment (%d-%d replicas) and may override this change; edit the HPA instead to change replicas permanently",
+			hpa.Name, minReplicas, hpa.Spec.MaxReplicas)
+	}
+
+	return result, nil
+}
+
+// findDeploymentHPA returns the HPA targeting the deployment, or nil if none does
+func findDeploymentHPA(client kubernetes.Interface, namespace, name string) *autoscalingv2.HorizontalPodAutoscaler {
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil
+	}
+
+	for i := range hpas.Items {
+		ref := hpas.Items[i].Spec.ScaleTargetRef
+		if ref.Kind == "Deployment" && ref.Name == name {
+			return &hpas.Items[i]
+		}
+	}
+	return nil
 }
 
 // Restart triggers a rolling restart of a deployment
@@ -286,6 +371,249 @@ func (h *DeploymentHandler) Restart(ctx *gofr.Context) (interface{}, error) {
 	}, nil
 }
 
+type setImageRequest struct {
+	Container string `json:"container"` // May be omitted when the pod has one container
+	Image     string `json:"image"`
+}
+
+// SetImage changes one container's image with a strategic merge patch keyed on
+// the container name, like kubectl set image
+func (h *DeploymentHandler) SetImage(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	var req setImageRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Image) == "" {
+		return nil, badRequestError{"image is required"}
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	containers := deployment.Spec.Template.Spec.Containers
+	names := make([]string, 0, len(containers))
+	previous := ""
+	for _, c := range containers {
+		names = append(names, c.Name)
+		if c.Name == req.Container {
+			previous = c.Image
+		}
+	}
+	if req.Container == "" && len(containers) == 1 {
+		req.Container, previous = containers[0].Name, containers[0].Image
+	}
+	if previous == "" {
+		return nil, badRequestError{fmt.Sprintf("container %q not found in deployment %s; valid containers: %s",
+			req.Container, name, strings.Join(names, ", "))}
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []map[string]string{{"name": req.Container, "image": req.Image}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.AppsV1().Deployments(namespace).Patch(context.Background(), name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"message":       fmt.Sprintf("Deployment %s container %s image set to %s", name, req.Container, req.Image),
+		"container":     req.Container,
+		"image":         req.Image,
+		"previousImage": previous,
+	}, nil
+}
+
+// templateMetadataRequest edits pod template labels and annotations. A null
+// value removes the key; keys not mentioned are left unchanged.
+type templateMetadataRequest struct {
+	Labels      map[string]*string `json:"labels"`
+	Annotations map[string]*string `json:"annotations"`
+}
+
+// PatchTemplateMetadata merges label and annotation changes into the pod
+// template. This always starts a rollout, so it is kept separate from edits to
+// the deployment's own metadata.
+func (h *DeploymentHandler) PatchTemplateMetadata(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	var req templateMetadataRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+	if len(req.Labels) == 0 && len(req.Annotations) == 0 {
+		return nil, fmt.Errorf("labels or annotations are required")
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{}
+	if len(req.Labels) > 0 {
+		metadata["labels"] = req.Labels
+	}
+	if len(req.Annotations) > 0 {
+		metadata["annotations"] = req.Annotations
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{"metadata": metadata},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The API server rejects label changes that would stop the selector matching the template
+	deployment, err := client.AppsV1().Deployments(namespace).Patch(context.Background(), name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"message":             fmt.Sprintf("Deployment %s pod template updated, rollout started", name),
+		"templateLabels":      deployment.Spec.Template.Labels,
+		"templateAnnotations": deployment.Spec.Template.Annotations,
+	}, nil
+}
+
+type createDeploymentRequest struct {
+	Name      string          `json:"name"`
+	Image     string          `json:"image"`
+	Replicas  *int32          `json:"replicas"`
+	Ports     []ContainerPort `json:"ports"`
+	Env       []EnvVar        `json:"env"` // only name/value are used
+	Resources struct {
+		Requests map[string]string `json:"requests"` // e.g. {"cpu": "100m", "memory": "128Mi"}
+		Limits   map[string]string `json:"limits"`
+	} `json:"resources"`
+}
+
+// Create builds a single-container deployment from a structured request
+func (h *DeploymentHandler) Create(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+
+	var req createDeploymentRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if req.Image == "" {
+		return nil, fmt.Errorf("image is required")
+	}
+
+	replicas := int32(1)
+	if req.Replicas != nil {
+		replicas = *req.Replicas
+	}
+
+	requests, err := parseResourceList(req.Resources.Requests)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource requests: %w", err)
+	}
+	limits, err := parseResourceList(req.Resources.Limits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource limits: %w", err)
+	}
+
+	container := corev1.Container{
+		Name:  req.Name,
+		Image: req.Image,
+		Resources: corev1.ResourceRequirements{
+			Requests: requests,
+			Limits:   limits,
+		},
+	}
+
+	for _, p := range req.Ports {
+		protocol := corev1.ProtocolTCP
+		if p.Protocol != "" {
+			protocol = corev1.Protocol(p.Protocol)
+		}
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          p.Name,
+			ContainerPort: p.ContainerPort,
+			Protocol:      protocol,
+		})
+	}
+
+	for _, e := range req.Env {
+		container.Env = append(container.Env, corev1.EnvVar{Name: e.Name, Value: e.Value})
+	}
+
+	labels := map[string]string{"app": req.Name}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{container},
+				},
+			},
+		},
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.AppsV1().Deployments(namespace).Create(context.Background(), deployment, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return deploymentToInfo(created, false), nil
+}
+
+// parseResourceList converts a name->quantity string map into a ResourceList
+func parseResourceList(values map[string]string) (corev1.ResourceList, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	list := make(corev1.ResourceList)
+	for name, value := range values {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		list[corev1.ResourceName(name)] = q
+	}
+	return list, nil
+}
+
 // Delete removes a deployment
 func (h *DeploymentHandler) Delete(ctx *gofr.Context) (interface{}, error) {
 	namespace := ctx.PathParam("namespace")
@@ -304,23 +632,35 @@ func (h *DeploymentHandler) Delete(ctx *gofr.Context) (interface{}, error) {
 	return map[string]string{"message": fmt.Sprintf("Deployment %s deleted", name)}, nil
 }
 
-// Events returns events for a specific deployment
+// Events returns events for a specific deployment. With includeChildren=true
+// it also returns events for its ReplicaSets and their pods, where image pull
+// and scheduling failures show up, merged in chronological order.
 func (h *DeploymentHandler) Events(ctx *gofr.Context) (interface{}, error) {
 	namespace := ctx.PathParam("namespace")
 	name := ctx.PathParam("name")
+	includeChildren := ctx.Param("includeChildren") == "true"
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	// Get events filtered by the deployment
-	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=Deployment", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
-		FieldSelector: fieldSelector,
-	})
-	if err != nil {
-		return nil, err
+	var items []corev1.Event
+	if includeChildren {
+		items, err = deploymentTreeEvents(client, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Get events filtered by the deployment
+		fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=Deployment", name, namespace)
+		events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+			FieldSelector: fieldSelector,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		items = events.Items
 	}
 
 	type DeploymentEvent struct {
@@ -329,10 +669,11 @@ func (h *DeploymentHandler) Events(ctx *gofr.Context) (interface{}, error) {
 		Message string `json:"message"`
 		Count   int32  `json:"count"`
 		Age     string `json:"age"`
+		Object  string `json:"object,omitempty"` // Kind/name, set when children are included
 	}
 
 	var result []DeploymentEvent
-	for _, event := range events.Items {
+	for _, event := range items {
 		age := ""
 		if !event.LastTimestamp.IsZero() {
 			age = formatAge(event.LastTimestamp.Time)
@@ -340,23 +681,350 @@ func (h *DeploymentHandler) Events(ctx *gofr.Context) (interface{}, error) {
 			age = formatAge(event.EventTime.Time)
 		}
 
-		result = append(result, DeploymentEvent{
+		entry := DeploymentEvent{
 			Type:    event.Type,
 			Reason:  event.Reason,
 			Message: event.Message,
 			Count:   event.Count,
 			Age:     age,
-		})
+		}
+		if includeChildren {
+			entry.Object = event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name
+		}
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+// deploymentTreeEvents returns the events of a deployment, its ReplicaSets and
+// their pods, oldest first. Objects are matched by UID, so events of pods that
+// have since been deleted are left out.
+func deploymentTreeEvents(client kubernetes.Interface, namespace, name string) ([]corev1.Event, error) {
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	replicaSets, err := deploymentReplicaSets(client, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := map[types.UID]bool{deployment.UID: true}
+	rsUIDs := make(map[types.UID]bool)
+	for _, rs := range replicaSets {
+		owned[rs.UID] = true
+		rsUIDs[rs.UID] = true
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		if owner := metav1.GetControllerOf(&pods.Items[i]); owner != nil && rsUIDs[owner.UID] {
+			owned[pods.Items[i].UID] = true
+		}
+	}
+
+	// One namespace-wide list is cheaper than a filtered list per object
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
 	}
 
+	var result []corev1.Event
+	for _, event := range events.Items {
+		if owned[event.InvolvedObject.UID] {
+			result = append(result, event)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return eventTime(&result[i]).Before(eventTime(&result[j]))
+	})
 	return result, nil
 }
 
+// healthEventWindow is how far back warning events count against a deployment's health
+const healthEventWindow = 15 * time.Minute
+
+// healthRestartWindow is how far back container restarts count against a deployment's health
+const healthRestartWindow = time.Hour
+
+// DeploymentHealth is a single health verdict for a deployment
+type DeploymentHealth struct {
+	Status  string   `json:"status"` // "healthy", "progressing", or "degraded"
+	Reasons []string `json:"reasons"`
+}
+
+// Health combines replica readiness, rollout conditions, recent warning events,
+// and container restarts into one verdict
+func (h *DeploymentHandler) Health(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var degraded, progressing []string
+
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	// Rollout conditions
+	stalled := false
+	for _, c := range deployment.Status.Conditions {
+		switch {
+		case c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded":
+			stalled = true
+			degraded = append(degraded, fmt.Sprintf("rollout stalled: %s", c.Message))
+		case c.Type == appsv1.DeploymentReplicaFailure && c.Status == corev1.ConditionTrue:
+			degraded = append(degraded, fmt.Sprintf("replica failure: %s", c.Message))
+		case c.Type == appsv1.DeploymentAvailable && c.Status == corev1.ConditionFalse:
+			degraded = append(degraded, fmt.Sprintf("unavailable: %s", c.Message))
+		}
+	}
+	// Replica readiness. Missing ready replicas are normal during a rollout or
+	// scale-up, so they only count as degraded once the rollout has stalled.
+	if deployment.Status.ReadyReplicas < desired {
+		reason := fmt.Sprintf("%d/%d replicas ready", deployment.Status.ReadyReplicas, desired)
+		if stalled {
+			degraded = append(degraded, reason)
+		} else {
+			progressing = append(progressing, reason)
+		}
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation || deployment.Status.UpdatedReplicas < desired {
+		progressing = append(progressing, fmt.Sprintf("rollout in progress: %d/%d replicas updated", deployment.Status.UpdatedReplicas, desired))
+	}
+
+	// Container restarts and crash loops in member pods
+	podNames := map[string]bool{}
+	if deployment.Spec.Selector != nil {
+		pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range pods.Items {
+			podNames[pod.Name] = true
+			for _, cs := range pod.Status.ContainerStatuses {
+				if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+					degraded = append(degraded, fmt.Sprintf("%s/%s is in CrashLoopBackOff (%d restarts)", pod.Name, cs.Name, cs.RestartCount))
+					continue
+				}
+				if last := cs.LastTerminationState.Terminated; last != nil && time.Since(last.FinishedAt.Time) < healthRestartWindow {
+					degraded = append(degraded, fmt.Sprintf("%s/%s restarted %s ago (%s, %d restarts)", pod.Name, cs.Name, formatAge(last.FinishedAt.Time), last.Reason, cs.RestartCount))
+				}
+			}
+		}
+	}
+
+	// Recent warning events on the deployment or its pods
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+		FieldSelector: "type=Warning",
+	}))
+	if err != nil {
+		return nil, err
+	}
+	warnings := map[string]int32{}
+	for _, event := range events.Items {
+		involved := event.InvolvedObject
+		if !(involved.Kind == "Deployment" && involved.Name == name) && !(involved.Kind == "Pod" && podNames[involved.Name]) {
+			continue
+		}
+
+		last := event.LastTimestamp.Time
+		if last.IsZero() {
+			last = event.EventTime.Time
+		}
+		if time.Since(last) > healthEventWindow {
+			continue
+		}
+
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+		warnings[event.Reason] += count
+	}
+	var warningReasons []string
+	for reason := range warnings {
+		warningReasons = append(warningReasons, reason)
+	}
+	sort.Strings(warningReasons)
+	for _, reason := range warningReasons {
+		degraded = append(degraded, fmt.Sprintf("%d %s warning events in the last %s", warnings[reason], reason, healthEventWindow))
+	}
+
+	health := DeploymentHealth{Status: "healthy", Reasons: []string{}}
+	if len(degraded) > 0 {
+		health.Status = "degraded"
+	} else if len(progressing) > 0 {
+		health.Status = "progressing"
+	}
+	health.Reasons = append(health.Reasons, degraded...)
+	health.Reasons = append(health.Reasons, progressing...)
+
+	return health, nil
+}
+
+// PendingChange describes how a deployment's pod template differs from its active ReplicaSet
+type PendingChange struct {
+	Pending    bool             `json:"pending"`
+	ReplicaSet string           `json:"replicaSet,omitempty"`
+	Revision   string           `json:"revision,omitempty"`
+	Changes    []TemplateChange `json:"changes"`
+}
+
+// TemplateChange is one differing field, addressed by a JSON path like spec.containers[0].image
+type TemplateChange struct {
+	Path    string `json:"path"`
+	Current string `json:"current,omitempty"` // Active ReplicaSet value
+	Desired string `json:"desired,omitempty"` // Deployment value
+}
+
+// PendingChangeDiff diffs the deployment's pod template against its active ReplicaSet's template
+func (h *DeploymentHandler) PendingChangeDiff(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	// The active ReplicaSet carries the same revision annotation as the deployment
+	const revisionAnnotation = "deployment.kubernetes.io/revision"
+	revision := deployment.Annotations[revisionAnnotation]
+	var active *appsv1.ReplicaSet
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		if owner := metav1.GetControllerOf(rs); owner == nil || owner.UID != deployment.UID {
+			continue
+		}
+		if rs.Annotations[revisionAnnotation] == revision {
+			active = rs
+			break
+		}
+	}
+
+	result := PendingChange{Changes: []TemplateChange{}}
+	if active == nil {
+		result.Pending = true
+		return result, nil
+	}
+	result.ReplicaSet = active.Name
+	result.Revision = revision
+
+	// The controller adds pod-template-hash to the ReplicaSet's template labels
+	rsTemplate := active.Spec.Template.DeepCopy()
+	delete(rsTemplate.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	current, err := flattenObject(rsTemplate)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := flattenObject(&deployment.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]bool)
+	for path := range current {
+		paths[path] = true
+	}
+	for path := range desired {
+		paths[path] = true
+	}
+	for path := range paths {
+		if current[path] != desired[path] {
+			result.Changes = append(result.Changes, TemplateChange{Path: path, Current: current[path], Desired: desired[path]})
+		}
+	}
+	sort.Slice(result.Changes, func(i, j int) bool {
+		return result.Changes[i].Path < result.Changes[j].Path
+	})
+
+	result.Pending = len(result.Changes) > 0
+	return result, nil
+}
+
+// flattenObject converts an object to a map of JSON path to scalar value
+func flattenObject(obj interface{}) (map[string]string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string)
+	flattenValue("", value, out)
+	return out, nil
+}
+
+func flattenValue(path string, value interface{}, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			flattenValue(childPath, child, out)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenValue(fmt.Sprintf("%s[%d]", path, i), child, out)
+		}
+	case string:
+		out[path] = v
+	case float64:
+		out[path] = strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+	default:
+		out[path] = fmt.Sprint(v)
+	}
+}
+
 func deploymentToInfo(d *appsv1.Deployment, detailed bool) DeploymentInfo {
-	return deploymentToInfoWithRunningContainers(d, nil, nil, "")
+	return deploymentToInfoWithRunningContainers(d, nil, nil, "", nil)
 }
 
-func deploymentToInfoWithRunningContainers(d *appsv1.Deployment, runningContainers []RunningContainer, client kubernetes.Interface, namespace string) DeploymentInfo {
+// deploymentToInfoWithRunningContainers resolves env values from ConfigMaps and
+// Secrets when a client is given; Secret values are skipped, keeping only the
+// secret:name/key reference, when the filter disables secrets.
+func deploymentToInfoWithRunningContainers(d *appsv1.Deployment, runningContainers []RunningContainer, client kubernetes.Interface, namespace string, filter *ResourceFilter) DeploymentInfo {
 	replicas := int32(0)
 	if d.Spec.Replicas != nil {
 		replicas = *d.Spec.Replicas
@@ -374,6 +1042,9 @@ func deploymentToInfoWithRunningContainers(d *appsv1.Deployment, runningContaine
 
 	info.Labels = d.Labels
 	info.Strategy = string(d.Spec.Strategy.Type)
+	info.TerminationGracePeriodSeconds = d.Spec.Template.Spec.TerminationGracePeriodSeconds
+	info.TemplateLabels = d.Spec.Template.Labels
+	info.TemplateAnnotations = d.Spec.Template.Annotations
 	if d.Spec.Selector != nil {
 		info.Selector = d.Spec.Selector.MatchLabels
 	}
@@ -383,8 +1054,9 @@ func deploymentToInfoWithRunningContainers(d *appsv1.Deployment, runningContaine
 		info.Images = append(info.Images, c.Image)
 
 		container := DeploymentContainer{
-			Name:  c.Name,
-			Image: c.Image,
+			Name:      c.Name,
+			Image:     c.Image,
+			Lifecycle: containerLifecycle(c.Lifecycle),
 		}
 
 		// Parse resource requests/limits
@@ -442,7 +1114,7 @@ func deploymentToInfoWithRunningContainers(d *appsv1.Deployment, runningContaine
 				prefix := ef.Prefix
 				secretName := ef.SecretRef.Name
 				// Try to fetch the Secret and expand keys with values
-				if client != nil && namespace != "" {
+				if client != nil && namespace != "" && filter.Enabled("secrets") {
 					secret, err := client.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
 					if err == nil {
 						for key, value := range secret.Data {
@@ -463,6 +1135,8 @@ func deploymentToInfoWithRunningContainers(d *appsv1.Deployment, runningContaine
 			}
 		}
 
+		fromCount := len(container.Env)
+
 		// Parse environment variables
 		for _, e := range c.Env {
 			ev := EnvVar{Name: e.Name}
@@ -487,7 +1161,7 @@ func deploymentToInfoWithRunningContainers(d *appsv1.Deployment, runningContaine
 					secretKey := e.ValueFrom.SecretKeyRef.Key
 					ev.ValueFrom = fmt.Sprintf("secret:%s/%s", secretName, secretKey)
 					// Fetch actual value from Secret
-					if client != nil && namespace != "" {
+					if client != nil && namespace != "" && filter.Enabled("secrets") {
 						secret, err := client.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
 						if err == nil {
 							if val, ok := secret.Data[secretKey]; ok {
@@ -503,6 +1177,7 @@ func deploymentToInfoWithRunningContainers(d *appsv1.Deployment, runningContaine
 			}
 			container.Env = append(container.Env, ev)
 		}
+		container.EnvConflicts = envConflicts(container.Env, fromCount)
 
 		info.ContainerDetails = append(info.ContainerDetails, container)
 	}
@@ -522,3 +1197,152 @@ func deploymentToInfoWithRunningContainers(d *appsv1.Deployment, runningContaine
 
 	return info
 }
+
+const (
+	// recommendationHeadroom is added on top of peak usage when suggesting requests
+	recommendationHeadroom = 1.2
+	// memoryLimitHeadroom is added on top of peak memory when suggesting a limit
+	memoryLimitHeadroom = 1.5
+	// overProvisionedRatio flags requests where peak usage is below this share of the request
+	overProvisionedRatio = 0.5
+
+	minCPURecommendation    = 10               // millicores
+	minMemoryRecommendation = 32 * 1024 * 1024 // bytes
+)
+
+// DeploymentRecommendations suggests container requests/limits from observed usage
+type DeploymentRecommendations struct {
+	Name       string                    `json:"name"`
+	Namespace  string                    `json:"namespace"`
+	Samples    int                       `json:"samples"` // Running containers with metrics
+	Note       string                    `json:"note"`
+	Containers []ContainerRecommendation `json:"containers"`
+}
+
+type ContainerRecommendation struct {
+	Container string                 `json:"container"`
+	CPU       ResourceRecommendation `json:"cpu"`    // millicores
+	Memory    ResourceRecommendation `json:"memory"` // bytes
+}
+
+type ResourceRecommendation struct {
+	Request          int64  `json:"request"`
+	Limit            int64  `json:"limit"`
+	PeakUsage        int64  `json:"peakUsage"`
+	AverageUsage     int64  `json:"averageUsage"`
+	SuggestedRequest int64  `json:"suggestedRequest"`
+	SuggestedLimit   int64  `json:"suggestedLimit,omitempty"`
+	Status           string `json:"status"` // ok, over-provisioned, under-provisioned, unset or no-data
+	Reason           string `json:"reason,omitempty"`
+}
+
+// Recommendations compares the current metrics of every replica with the pod
+// template's requests and limits. It is based on a single metrics reading, so
+// suggestions reflect the present load rather than a long-term peak.
+func (h *DeploymentHandler) Recommendations(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.k8s.GetMetricsClient(); err != nil {
+		return nil, fmt.Errorf("metrics are unavailable: %w", err)
+	}
+
+	var selector map[string]string
+	if deployment.Spec.Selector != nil {
+		selector = deployment.Spec.Selector.MatchLabels
+	}
+	running := h.fetchRunningContainers(namespace, selector)
+
+	// Containers without metrics (e.g. not yet scraped) report zero usage and are skipped
+	cpuUsage := make(map[string][]int64)
+	memoryUsage := make(map[string][]int64)
+	samples := 0
+	for _, rc := range running {
+		if rc.CPU.Usage == 0 && rc.Memory.Usage == 0 {
+			continue
+		}
+		samples++
+		cpuUsage[rc.ContainerName] = append(cpuUsage[rc.ContainerName], rc.CPU.Usage)
+		memoryUsage[rc.ContainerName] = append(memoryUsage[rc.ContainerName], rc.Memory.Usage)
+	}
+
+	result := DeploymentRecommendations{
+		Name:       deployment.Name,
+		Namespace:  deployment.Namespace,
+		Samples:    samples,
+		Note:       "Based on a single metrics reading across current replicas; confirm against peak load before applying",
+		Containers: []ContainerRecommendation{},
+	}
+
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		cpu := recommendResource(c.Resources.Requests.Cpu().MilliValue(), c.Resources.Limits.Cpu().MilliValue(), cpuUsage[c.Name], minCPURecommendation)
+		memory := recommendResource(c.Resources.Requests.Memory().Value(), c.Resources.Limits.Memory().Value(), memoryUsage[c.Name], minMemoryRecommendation)
+
+		// CPU is throttled at its limit but memory is OOM-killed, so only memory gets a suggested limit
+		if memory.Status != "no-data" {
+			memory.SuggestedLimit = int64(float64(memory.PeakUsage) * memoryLimitHeadroom)
+			if memory.SuggestedLimit < memory.SuggestedRequest {
+				memory.SuggestedLimit = memory.SuggestedRequest
+			}
+		}
+
+		result.Containers = append(result.Containers, ContainerRecommendation{
+			Container: c.Name,
+			CPU:       cpu,
+			Memory:    memory,
+		})
+	}
+
+	return result, nil
+}
+
+// recommendResource compares usage samples with a request/limit pair and suggests a request
+func recommendResource(request, limit int64, usage []int64, minimum int64) ResourceRecommendation {
+	rec := ResourceRecommendation{Request: request, Limit: limit}
+	if len(usage) == 0 {
+		rec.Status = "no-data"
+		return rec
+	}
+
+	var total int64
+	for _, u := range usage {
+		total += u
+		if u > rec.PeakUsage {
+			rec.PeakUsage = u
+		}
+	}
+	rec.AverageUsage = total / int64(len(usage))
+
+	rec.SuggestedRequest = int64(float64(rec.PeakUsage) * recommendationHeadroom)
+	if rec.SuggestedRequest < minimum {
+		rec.SuggestedRequest = minimum
+	}
+
+	switch {
+	case request == 0:
+		rec.Status = "unset"
+		rec.Reason = "No request is set, so the scheduler can't reserve capacity"
+	case limit > 0 && rec.PeakUsage >= limit*9/10:
+		rec.Status = "under-provisioned"
+		rec.Reason = "Peak usage is at or above 90% of the limit"
+	case rec.PeakUsage > request:
+		rec.Status = "under-provisioned"
+		rec.Reason = "Peak usage exceeds the request"
+	case float64(rec.PeakUsage) < float64(request)*overProvisionedRatio && rec.SuggestedRequest < request:
+		rec.Status = "over-provisioned"
+		rec.Reason = "Peak usage is below half of the request"
+	default:
+		rec.Status = "ok"
+	}
+	return rec
+}
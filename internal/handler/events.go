@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/opengittr/kubeui/internal/service"
@@ -34,51 +35,56 @@ type EventInfo struct {
 }
 
 func (h *EventHandler) List(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{})
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
 
 	var result []EventInfo
 	for _, event := range events.Items {
-		object := fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name)
+		result = append(result, eventToInfo(&event))
+	}
 
-		firstTimestamp := ""
-		if !event.FirstTimestamp.IsZero() {
-			firstTimestamp = formatAge(event.FirstTimestamp.Time)
-		}
+	return result, nil
+}
 
-		lastTimestamp := ""
-		age := ""
-		if !event.LastTimestamp.IsZero() {
-			lastTimestamp = formatAge(event.LastTimestamp.Time)
-			age = lastTimestamp
-		} else if !event.EventTime.IsZero() {
-			age = formatAge(event.EventTime.Time)
-		}
+// eventToInfo converts a core event to its API representation
+func eventToInfo(event *corev1.Event) EventInfo {
+	object := fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name)
 
-		result = append(result, EventInfo{
-			Name:           event.Name,
-			Namespace:      event.Namespace,
-			Type:           event.Type,
-			Reason:         event.Reason,
-			Message:        event.Message,
-			Object:         object,
-			Count:          event.Count,
-			FirstTimestamp: firstTimestamp,
-			LastTimestamp:  lastTimestamp,
-			Age:            age,
-		})
+	firstTimestamp := ""
+	if !event.FirstTimestamp.IsZero() {
+		firstTimestamp = formatAge(event.FirstTimestamp.Time)
 	}
 
-	return result, nil
+	lastTimestamp := ""
+	age := ""
+	if !event.LastTimestamp.IsZero() {
+		lastTimestamp = formatAge(event.LastTimestamp.Time)
+		age = lastTimestamp
+	} else if !event.EventTime.IsZero() {
+		age = formatAge(event.EventTime.Time)
+	}
+
+	return EventInfo{
+		Name:           event.Name,
+		Namespace:      event.Namespace,
+		Type:           event.Type,
+		Reason:         event.Reason,
+		Message:        event.Message,
+		Object:         object,
+		Count:          event.Count,
+		FirstTimestamp: firstTimestamp,
+		LastTimestamp:  lastTimestamp,
+		Age:            age,
+	}
 }
 
 // WarningEventGroup represents a group of similar warning events
@@ -95,14 +101,14 @@ type WarningEventGroup struct {
 
 // ListWarnings returns warning events from the last 24h, grouped and deduplicated
 func (h *EventHandler) ListWarnings(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{})
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -172,3 +178,92 @@ func (h *EventHandler) ListWarnings(ctx *gofr.Context) (interface{}, error) {
 
 	return result, nil
 }
+
+// ObjectEventGroup summarizes the events recorded against one object
+type ObjectEventGroup struct {
+	Object        string `json:"object"`
+	ObjectKind    string `json:"objectKind"`
+	ObjectName    string `json:"objectName"`
+	Namespace     string `json:"namespace"`
+	Normal        int32  `json:"normal"`
+	Warning       int32  `json:"warning"`
+	LatestType    string `json:"latestType"`
+	LatestReason  string `json:"latestReason"`
+	LatestMessage string `json:"latestMessage"`
+	LastSeen      string `json:"lastSeen"`
+}
+
+// ListByObject groups events by involved object with Normal/Warning counts, noisiest first
+func (h *EventHandler) ListByObject(ctx *gofr.Context) (interface{}, error) {
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*ObjectEventGroup)
+	latest := make(map[string]time.Time)
+
+	for _, event := range events.Items {
+		eventTime := event.LastTimestamp.Time
+		if eventTime.IsZero() {
+			eventTime = event.EventTime.Time
+		}
+		if eventTime.IsZero() {
+			eventTime = event.FirstTimestamp.Time
+		}
+
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+
+		object := fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name)
+		key := fmt.Sprintf("%s|%s", event.Namespace, object)
+
+		group, ok := groups[key]
+		if !ok {
+			group = &ObjectEventGroup{
+				Object:     object,
+				ObjectKind: event.InvolvedObject.Kind,
+				ObjectName: event.InvolvedObject.Name,
+				Namespace:  event.Namespace,
+			}
+			groups[key] = group
+		}
+
+		if event.Type == "Warning" {
+			group.Warning += count
+		} else {
+			group.Normal += count
+		}
+
+		if !ok || eventTime.After(latest[key]) {
+			latest[key] = eventTime
+			group.LatestType = event.Type
+			group.LatestReason = event.Reason
+			group.LatestMessage = event.Message
+			group.LastSeen = formatAge(eventTime)
+		}
+	}
+
+	result := make([]ObjectEventGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Warning != result[j].Warning {
+			return result[i].Warning > result[j].Warning
+		}
+		return result[i].Normal+result[i].Warning > result[j].Normal+result[j].Warning
+	})
+
+	return result, nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/opengittr/kubeui/internal/service"
@@ -17,16 +18,22 @@ import (
 	"k8s.io/client-go/tools/remotecommand"
 )
 
+// podLookupTimeout bounds the initial pod Get done before starting exec or port-forward,
+// so a hung API server doesn't leave the handler blocked
+const podLookupTimeout = 10 * time.Second
+
 // ExecHandler handles pod exec WebSocket connections
 type ExecHandler struct {
 	k8sManager *service.K8sManager
 	upgrader   websocket.Upgrader
+	recorder   *ExecRecorder // nil when sessions aren't recorded
 }
 
-// NewExecHandler creates a new exec handler
-func NewExecHandler(k8sManager *service.K8sManager) *ExecHandler {
+// NewExecHandler creates a new exec handler. Sessions are recorded when recorder is non-nil.
+func NewExecHandler(k8sManager *service.K8sManager, recorder *ExecRecorder) *ExecHandler {
 	return &ExecHandler{
 		k8sManager: k8sManager,
+		recorder:   recorder,
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				return true // Allow all origins for local development
@@ -37,7 +44,7 @@ func NewExecHandler(k8sManager *service.K8sManager) *ExecHandler {
 
 // TerminalMessage represents a message between frontend and backend
 type TerminalMessage struct {
-	Type string `json:"type"` // "input", "output", "resize", "error"
+	Type string `json:"type"` // "input", "output", "resize", "error", "session"
 	Data string `json:"data,omitempty"`
 	Rows uint16 `json:"rows,omitempty"`
 	Cols uint16 `json:"cols,omitempty"`
@@ -45,14 +52,17 @@ type TerminalMessage struct {
 
 // wsWriter implements io.Writer for WebSocket
 type wsWriter struct {
-	conn *websocket.Conn
-	mu   sync.Mutex
+	conn      *websocket.Conn
+	mu        sync.Mutex
+	recording *execRecording
 }
 
 func (w *wsWriter) Write(p []byte) (int, error) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	w.recording.record("output", p)
+
 	msg := TerminalMessage{
 		Type: "output",
 		Data: string(p),
@@ -115,7 +125,9 @@ func (h *ExecHandler) HandleExec(w http.ResponseWriter, r *http.Request) {
 
 	// If no container specified, get the first one
 	if container == "" {
-		pod, err := client.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		lookupCtx, cancelLookup := context.WithTimeout(r.Context(), podLookupTimeout)
+		pod, err := client.CoreV1().Pods(namespace).Get(lookupCtx, name, metav1.GetOptions{})
+		cancelLookup()
 		if err != nil {
 			h.sendError(conn, fmt.Sprintf("Failed to get pod: %v", err))
 			return
@@ -147,8 +159,22 @@ func (h *ExecHandler) HandleExec(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Start recording before any input reaches the pod, telling the client its session ID
+	var recording *execRecording
+	if h.recorder != nil {
+		recording, err = h.recorder.start(namespace, name, container, shell)
+		if err != nil {
+			h.sendError(conn, fmt.Sprintf("Failed to start recording: %v", err))
+			return
+		}
+		defer recording.finish()
+
+		data, _ := json.Marshal(TerminalMessage{Type: "session", Data: recording.session.ID})
+		conn.WriteMessage(websocket.TextMessage, data)
+	}
+
 	// Create writer for output
-	writer := &wsWriter{conn: conn}
+	writer := &wsWriter{conn: conn, recording: recording}
 
 	// Create terminal size queue
 	termSize := &TerminalSize{
@@ -158,8 +184,8 @@ func (h *ExecHandler) HandleExec(w http.ResponseWriter, r *http.Request) {
 	// Create stdin pipe
 	stdinReader, stdinWriter := io.Pipe()
 
-	// Create context for cancellation
-	ctx, cancel := context.WithCancel(context.Background())
+	// Create context for cancellation, tied to the client connection
+	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
 
 	// Start goroutine to read from WebSocket and write to stdin
@@ -179,6 +205,7 @@ func (h *ExecHandler) HandleExec(w http.ResponseWriter, r *http.Request) {
 
 			switch msg.Type {
 			case "input":
+				recording.record("input", []byte(msg.Data))
 				stdinWriter.Write([]byte(msg.Data))
 			case "resize":
 				select {
@@ -206,6 +233,74 @@ func (h *ExecHandler) HandleExec(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// execInPod runs a non-interactive command in a pod container and waits for it to exit.
+// Streams that are nil are not requested from the API server.
+func execInPod(ctx context.Context, k8s *service.K8sManager, namespace, name, container string, command []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	config, err := k8s.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+
+	client, err := k8s.GetClient()
+	if err != nil {
+		return fmt.Errorf("failed to get client: %w", err)
+	}
+
+	req := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(name).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    stdout != nil,
+			Stderr:    stderr != nil,
+			TTY:       false,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	return exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// resolveContainer returns the given container name, or the pod's first container if empty
+func resolveContainer(ctx context.Context, k8s *service.K8sManager, namespace, name, container string) (string, error) {
+	if container != "" {
+		return container, nil
+	}
+
+	client, err := k8s.GetClient()
+	if err != nil {
+		return "", err
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod: %w", err)
+	}
+	return podContainer(pod, "")
+}
+
+// podContainer is resolveContainer for a pod that has already been fetched
+func podContainer(pod *corev1.Pod, container string) (string, error) {
+	if container != "" {
+		return container, nil
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return "", fmt.Errorf("pod %s has no containers", pod.Name)
+	}
+	return pod.Spec.Containers[0].Name, nil
+}
+
 func (h *ExecHandler) sendError(conn *websocket.Conn, message string) {
 	msg := TerminalMessage{
 		Type: "error",
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+const (
+	// defaultExecOnceTimeout bounds a one-shot command unless the request sets timeoutSeconds
+	defaultExecOnceTimeout = 30 * time.Second
+	// maxExecOnceTimeout caps timeoutSeconds; longer jobs belong in the terminal
+	maxExecOnceTimeout = 5 * time.Minute
+	// maxExecOnceOutput caps stdout and stderr each, so a stray `cat` of a large file stays cheap
+	maxExecOnceOutput = 1 << 20
+)
+
+type execOnceRequest struct {
+	Container      string   `json:"container"`
+	Command        []string `json:"command"`
+	TimeoutSeconds int      `json:"timeoutSeconds"`
+}
+
+// ExecResult is the output of a one-shot command
+type ExecResult struct {
+	Stdout    string `json:"stdout"`
+	Stderr    string `json:"stderr"`
+	ExitCode  int    `json:"exitCode"`
+	Truncated bool   `json:"truncated,omitempty"` // Output went past the limit and was cut
+	SessionID string `json:"sessionId,omitempty"` // Transcript ID when exec recording is on
+}
+
+// ExecOnce runs a command without a TTY and returns its output and exit code,
+// for quick actions that don't need an interactive session. A non-zero exit is
+// a normal result; only failures to run the command are errors.
+func (h *ExecHandler) ExecOnce(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	var req execOnceRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+	if len(req.Command) == 0 {
+		return nil, errors.New("command is required")
+	}
+
+	timeout := defaultExecOnceTimeout
+	if req.TimeoutSeconds > 0 {
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+		if timeout > maxExecOnceTimeout {
+			return nil, fmt.Errorf("timeoutSeconds can be at most %d", int(maxExecOnceTimeout.Seconds()))
+		}
+	}
+
+	container, err := resolveContainer(ctx, h.k8sManager, namespace, name, req.Container)
+	if err != nil {
+		return nil, err
+	}
+
+	var recording *execRecording
+	if h.recorder != nil {
+		recording, err = h.recorder.start(namespace, name, container, strings.Join(req.Command, " "))
+		if err != nil {
+			return nil, fmt.Errorf("failed to start recording: %w", err)
+		}
+		defer recording.finish()
+		recording.record("input", []byte(strings.Join(req.Command, " ")+"\n"))
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stdout := &cappedBuffer{max: maxExecOnceOutput}
+	stderr := &cappedBuffer{max: maxExecOnceOutput}
+	err = execInPod(execCtx, h.k8sManager, namespace, name, container, req.Command, nil, stdout, stderr)
+
+	result := ExecResult{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+	if recording != nil {
+		result.SessionID = recording.session.ID
+		recording.record("output", []byte(result.Stdout+result.Stderr))
+	}
+
+	var exitErr utilexec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitStatus()
+	case execCtx.Err() == context.DeadlineExceeded:
+		return nil, fmt.Errorf("command timed out after %s", timeout)
+	default:
+		return nil, fmt.Errorf("exec failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// cappedBuffer keeps the first max bytes written to it and drops the rest
+type cappedBuffer struct {
+	bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); len(p) > room {
+		b.truncated = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
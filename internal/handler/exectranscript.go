@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+const (
+	// maxExecSessions is how many recorded sessions are listed, and kept in memory
+	maxExecSessions = 100
+	// maxTranscriptBytes caps the input and output kept in memory per session
+	maxTranscriptBytes = 4 << 20
+)
+
+var errExecRecordingDisabled = errors.New("exec recording is disabled; start kubeui with --record-exec")
+
+// execSessionIDPattern matches the IDs start generates, which also name transcript files
+var execSessionIDPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// ExecRecorder records exec session transcripts for audit. With a directory
+// each session is appended to <dir>/<id>.jsonl as it happens; otherwise recent
+// sessions are kept in memory.
+type ExecRecorder struct {
+	dir      string
+	sessions []*execRecording // oldest first
+	mu       sync.Mutex
+}
+
+// NewExecRecorder creates a recorder, creating dir if it is set
+func NewExecRecorder(dir string) (*ExecRecorder, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create transcript directory: %w", err)
+		}
+	}
+	return &ExecRecorder{dir: dir}, nil
+}
+
+// ExecSession describes one recorded exec session
+type ExecSession struct {
+	ID        string `json:"id"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Shell     string `json:"shell"`
+	StartedAt string `json:"startedAt"`
+	EndedAt   string `json:"endedAt,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// TranscriptEntry is one chunk of terminal input or output
+type TranscriptEntry struct {
+	Time string `json:"time"`
+	Type string `json:"type"` // "input", "output" or, in files, "end"
+	Data string `json:"data,omitempty"`
+}
+
+type ExecTranscript struct {
+	ExecSession
+	Entries []TranscriptEntry `json:"entries"`
+}
+
+// execRecording is a session being or having been recorded. All methods are
+// no-ops on a nil recording so callers don't need to check whether recording is on.
+type execRecording struct {
+	session ExecSession
+	entries []TranscriptEntry
+	size    int
+	file    *os.File
+	mu      sync.Mutex
+}
+
+// start begins recording a session
+func (r *ExecRecorder) start(namespace, pod, container, shell string) (*execRecording, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	rec := &execRecording{session: ExecSession{
+		ID:        hex.EncodeToString(id),
+		Namespace: namespace,
+		Pod:       pod,
+		Container: container,
+		Shell:     shell,
+		StartedAt: time.Now().Format(time.RFC3339),
+	}}
+
+	if r.dir != "" {
+		// The first line of a transcript file is the session, the rest are entries
+		file, err := os.OpenFile(filepath.Join(r.dir, rec.session.ID+".jsonl"), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create transcript: %w", err)
+		}
+		if err := json.NewEncoder(file).Encode(rec.session); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to write transcript: %w", err)
+		}
+		rec.file = file
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions = append(r.sessions, rec)
+	if len(r.sessions) > maxExecSessions {
+		r.sessions = r.sessions[len(r.sessions)-maxExecSessions:]
+	}
+	return rec, nil
+}
+
+// record appends input or output to the transcript
+func (rec *execRecording) record(entryType string, data []byte) {
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	entry := TranscriptEntry{Time: time.Now().Format(time.RFC3339Nano), Type: entryType, Data: string(data)}
+	if rec.file != nil {
+		json.NewEncoder(rec.file).Encode(entry)
+		return
+	}
+
+	if rec.size+len(data) > maxTranscriptBytes {
+		rec.session.Truncated = true
+		return
+	}
+	rec.size += len(data)
+	rec.entries = append(rec.entries, entry)
+}
+
+// finish marks the session as ended and closes its file
+func (rec *execRecording) finish() {
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.session.EndedAt = time.Now().Format(time.RFC3339)
+	if rec.file != nil {
+		json.NewEncoder(rec.file).Encode(TranscriptEntry{Time: rec.session.EndedAt, Type: "end"})
+		rec.file.Close()
+		rec.file = nil
+	}
+}
+
+// ListSessions returns recorded exec sessions, newest first
+func (h *ExecHandler) ListSessions(ctx *gofr.Context) (interface{}, error) {
+	if h.recorder == nil {
+		return nil, errExecRecordingDisabled
+	}
+
+	h.recorder.mu.Lock()
+	defer h.recorder.mu.Unlock()
+
+	result := make([]ExecSession, 0, len(h.recorder.sessions))
+	for i := len(h.recorder.sessions) - 1; i >= 0; i-- {
+		rec := h.recorder.sessions[i]
+		rec.mu.Lock()
+		result = append(result, rec.session)
+		rec.mu.Unlock()
+	}
+	return result, nil
+}
+
+// Transcript returns the input and output of a recorded exec session. With a
+// transcript directory, sessions from before a restart can still be read by ID.
+func (h *ExecHandler) Transcript(ctx *gofr.Context) (interface{}, error) {
+	if h.recorder == nil {
+		return nil, errExecRecordingDisabled
+	}
+
+	id := ctx.PathParam("id")
+	if !execSessionIDPattern.MatchString(id) {
+		return nil, execSessionNotFound(id)
+	}
+
+	if h.recorder.dir != "" {
+		return readTranscriptFile(filepath.Join(h.recorder.dir, id+".jsonl"), id)
+	}
+
+	h.recorder.mu.Lock()
+	defer h.recorder.mu.Unlock()
+	for _, rec := range h.recorder.sessions {
+		if rec.session.ID != id {
+			continue
+		}
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		transcript := ExecTranscript{ExecSession: rec.session, Entries: make([]TranscriptEntry, len(rec.entries))}
+		copy(transcript.Entries, rec.entries)
+		return transcript, nil
+	}
+	return nil, execSessionNotFound(id)
+}
+
+// execSessionNotFound answers a transcript lookup for an unknown session with a 404
+func execSessionNotFound(id string) error {
+	return lookupFailure{message: fmt.Sprintf("exec session %q not found", id), status: http.StatusNotFound}
+}
+
+// readTranscriptFile parses a transcript file written by an execRecording
+func readTranscriptFile(path, id string) (interface{}, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, execSessionNotFound(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	transcript := ExecTranscript{Entries: []TranscriptEntry{}}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxTranscriptBytes)
+	for first := true; scanner.Scan(); first = false {
+		if first {
+			if err := json.Unmarshal(scanner.Bytes(), &transcript.ExecSession); err != nil {
+				return nil, fmt.Errorf("failed to parse transcript: %w", err)
+			}
+			continue
+		}
+
+		var entry TranscriptEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A partially written last line from a crash
+			transcript.Truncated = true
+			break
+		}
+		if entry.Type == "end" {
+			transcript.EndedAt = entry.Time
+			continue
+		}
+		transcript.Entries = append(transcript.Entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	return transcript, nil
+}
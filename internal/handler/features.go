@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+)
+
+// alwaysEnabled are API path segments that aren't resource types or features and can't be turned off
+var alwaysEnabled = map[string]bool{
+	"clusters": true,
+	"version":  true,
+	"search":   true,
+	"summary":  true,
+	"history":  true,
+	"stream":   true,
+	"batch":    true, // Each operation is checked against the filter
+
+	"recent-changes": true, // Each scanned type is checked against the filter
+	"orphans":        true, // Each scanned type is checked against the filter
+}
+
+// typedRoutes are generic routes whose second path segment names the resource type
+var typedRoutes = map[string]bool{
+	"yaml":       true,
+	"patch":      true,
+	"finalizers": true,
+}
+
+// routeResources maps fixed routes that aren't named after a resource type to
+// the types they read or write, so allow and deny lists apply to them too
+var routeResources = map[string][]string{
+	"images":             {"pods"},
+	"workloads/by-image": {"deployments", "statefulsets", "daemonsets"},
+}
+
+// namespaceRoutes maps /api/namespaces/{name}/{route} to the types the route reads or writes
+var namespaceRoutes = map[string][]string{
+	"ports":      {"namespaces", "pods", "services"},
+	"metrics":    {"namespaces", "pods"},
+	"scale-down": {"namespaces", "deployments", "statefulsets", "cronjobs"},
+}
+
+// podFeatures maps pod sub-resource path segments to the feature name that controls them
+var podFeatures = map[string]string{
+	"exec":         "exec",
+	"exec-once":    "exec",
+	"debug":        "exec", // Ephemeral debug containers are only useful through exec
+	"portforward":  "portforward",
+	"portforwards": "portforward",
+	"fs":           "files",
+	"download":     "files",
+	"cp":           "files",
+	"upload":       "files",
+	"logs":         "logs",
+}
+
+// ResourceFilter hides resource types (e.g. secrets) and features (exec,
+// portforward, files, logs, proxy) on locked-down instances
+type ResourceFilter struct {
+	enabled  map[string]bool // nil means everything not disabled is enabled
+	disabled map[string]bool
+}
+
+// NewResourceFilter builds a filter from comma-separated allow and deny lists.
+// The denylist wins when a name appears in both.
+func NewResourceFilter(enabled, disabled string) *ResourceFilter {
+	f := &ResourceFilter{disabled: parseNameList(disabled)}
+	if strings.TrimSpace(enabled) != "" {
+		f.enabled = parseNameList(enabled)
+	}
+	return f
+}
+
+func parseNameList(list string) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// Enabled reports whether a resource type or feature is available
+func (f *ResourceFilter) Enabled(name string) bool {
+	if f == nil || alwaysEnabled[name] {
+		return true
+	}
+	if f.disabled[name] {
+		return false
+	}
+	return f.enabled == nil || f.enabled[name]
+}
+
+// Middleware returns 404 for API requests that touch a disabled resource type or feature
+func (f *ResourceFilter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		for _, name := range f.requestNames(r) {
+			if !f.Enabled(name) {
+				http.NotFound(w, r)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestNames returns the resource types and features a request touches
+func (f *ResourceFilter) requestNames(r *http.Request) []string {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/"), "/")
+
+	if names, ok := routeResources[strings.Join(parts, "/")]; ok {
+		return names
+	}
+
+	switch {
+	case parts[0] == "logs":
+		// Selector-based log streaming
+		return []string{"logs", "pods"}
+	case parts[0] == "portforwards":
+		return []string{"portforward"}
+	case parts[0] == "proxy" && len(parts) > 1:
+		// Service and pod proxying is a feature of its own on top of the target type
+		return []string{"proxy", parts[1]}
+	case typedRoutes[parts[0]] && len(parts) > 1:
+		return []string{parts[1]}
+	case parts[0] == "tree" && len(parts) == 4:
+		// The root object, then the controller-created kinds indexed as children
+		return []string{parts[2], "replicasets", "jobs", "pods"}
+	case parts[0] == "namespaces" && len(parts) == 3 && namespaceRoutes[parts[2]] != nil:
+		return namespaceRoutes[parts[2]]
+	case parts[0] == "services" && len(parts) == 4 && parts[3] == "probe":
+		// Runs curl in a throwaway pod against the service
+		return []string{"services", "pods", "exec"}
+	case parts[0] == "deployments" && len(parts) == 5 && parts[3] == "logs":
+		// Log archive of every pod in the deployment
+		return []string{"deployments", "pods", "logs"}
+	case parts[0] == "deployments" && len(parts) == 4 && parts[3] == "runtime-env":
+		// Reads the environment by exec'ing into a pod
+		return []string{"deployments", "pods", "exec"}
+	case parts[0] == "pods" && len(parts) >= 4:
+		// Sub-resources like logs/stream are gated by their first segment
+		if feature, ok := podFeatures[parts[3]]; ok {
+			return []string{"pods", feature}
+		}
+	case r.URL.Path == "/api/events/watch":
+		// Live event watch
+		return []string{"events"}
+	case r.URL.Path == "/api/events/stream":
+		// Gated by the streamed resource, which defaults to pods
+		if resource := r.URL.Query().Get("resource"); resource != "" {
+			return []string{resource}
+		}
+		return []string{"pods"}
+	}
+	return []string{parts[0]}
+}
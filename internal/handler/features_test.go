@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResourceFilterRouteResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  string
+		disabled string
+		method   string
+		path     string
+		want     int
+	}{
+		{"images allowed with pods", "pods", "", http.MethodGet, "/api/images", http.StatusOK},
+		{"images denied with pods", "", "pods", http.MethodGet, "/api/images", http.StatusNotFound},
+		{"by-image allowed with workload types", "deployments,statefulsets,daemonsets", "", http.MethodGet, "/api/workloads/by-image", http.StatusOK},
+		{"by-image needs every workload type", "deployments,pods", "", http.MethodGet, "/api/workloads/by-image", http.StatusNotFound},
+		{"by-image denied with daemonsets", "", "daemonsets", http.MethodGet, "/api/workloads/by-image", http.StatusNotFound},
+		{"tree allowed with root and child types", "deployments,replicasets,jobs,pods", "", http.MethodGet, "/api/tree/default/deployments/web", http.StatusOK},
+		{"tree denied with root type", "", "deployments", http.MethodGet, "/api/tree/default/deployments/web", http.StatusNotFound},
+		{"tree denied with pods", "", "pods", http.MethodGet, "/api/tree/default/cronjobs/nightly", http.StatusNotFound},
+		{"probe allowed", "services,pods,exec", "", http.MethodPost, "/api/services/default/web/probe", http.StatusOK},
+		{"probe denied with pods", "", "pods", http.MethodPost, "/api/services/default/web/probe", http.StatusNotFound},
+		{"probe denied with exec", "", "exec", http.MethodPost, "/api/services/default/web/probe", http.StatusNotFound},
+		{"service get allowed without exec", "", "exec", http.MethodGet, "/api/services/default/web", http.StatusOK},
+		{"scale-down denied with deployments", "", "deployments", http.MethodPost, "/api/namespaces/default/scale-down", http.StatusNotFound},
+		{"namespace metrics denied with pods", "", "pods", http.MethodGet, "/api/namespaces/default/metrics", http.StatusNotFound},
+		{"namespace list allowed without pods", "", "pods", http.MethodGet, "/api/namespaces", http.StatusOK},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewResourceFilter(tt.enabled, tt.disabled)
+			rec := httptest.NewRecorder()
+			filter.Middleware(next).ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.want {
+				t.Errorf("%s %s with enabled=%q disabled=%q: status %d, want %d", tt.method, tt.path, tt.enabled, tt.disabled, rec.Code, tt.want)
+			}
+		})
+	}
+}
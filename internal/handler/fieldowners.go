@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FieldOwners lists which field manager owns which fields of an object
+type FieldOwners struct {
+	Managers []ManagedFieldsInfo `json:"managers"`
+	// Shared are fields owned by more than one manager, the usual sign of two
+	// controllers fighting over a value
+	Shared []SharedField `json:"shared"`
+}
+
+type ManagedFieldsInfo struct {
+	Manager     string   `json:"manager"`
+	Operation   string   `json:"operation"` // Apply or Update
+	Subresource string   `json:"subresource,omitempty"`
+	APIVersion  string   `json:"apiVersion"`
+	Time        string   `json:"time,omitempty"`
+	Fields      []string `json:"fields"`
+}
+
+type SharedField struct {
+	Path     string   `json:"path"`
+	Managers []string `json:"managers"`
+}
+
+// FieldOwners returns the field paths each manager owns on a namespaced resource
+func (h *YAMLHandler) FieldOwners(ctx *gofr.Context) (interface{}, error) {
+	return h.fieldOwners(ctx.PathParam("type"), ctx.PathParam("namespace"), ctx.PathParam("name"))
+}
+
+// FieldOwnersClusterScoped returns the field paths each manager owns on a cluster-scoped resource
+func (h *YAMLHandler) FieldOwnersClusterScoped(ctx *gofr.Context) (interface{}, error) {
+	return h.fieldOwners(ctx.PathParam("type"), "", ctx.PathParam("name"))
+}
+
+func (h *YAMLHandler) fieldOwners(resourceType, namespace, name string) (interface{}, error) {
+	gvr, err := gvrFor(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := resourceClient(h.k8s, gvr, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := resource.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := FieldOwners{Managers: []ManagedFieldsInfo{}, Shared: []SharedField{}}
+	owners := make(map[string][]string)
+
+	for _, entry := range obj.GetManagedFields() {
+		info := ManagedFieldsInfo{
+			Manager:     entry.Manager,
+			Operation:   string(entry.Operation),
+			Subresource: entry.Subresource,
+			APIVersion:  entry.APIVersion,
+			Fields:      []string{},
+		}
+		if entry.Time != nil {
+			info.Time = entry.Time.Format(time.RFC3339)
+		}
+
+		if entry.FieldsV1 != nil {
+			var fieldSet map[string]interface{}
+			if err := json.Unmarshal(entry.FieldsV1.Raw, &fieldSet); err != nil {
+				return nil, fmt.Errorf("failed to parse managed fields of %s: %w", entry.Manager, err)
+			}
+			info.Fields = flattenFieldSet(fieldSet, "")
+			sort.Strings(info.Fields)
+		}
+
+		for _, path := range info.Fields {
+			owners[path] = append(owners[path], entry.Manager)
+		}
+		result.Managers = append(result.Managers, info)
+	}
+
+	for path, managers := range owners {
+		if len(managers) > 1 {
+			result.Shared = append(result.Shared, SharedField{Path: path, Managers: managers})
+		}
+	}
+	sort.Slice(result.Shared, func(i, j int) bool {
+		return result.Shared[i].Path < result.Shared[j].Path
+	})
+
+	return result, nil
+}
+
+// flattenFieldSet turns a FieldsV1 set into readable paths such as
+// .spec.template.spec.containers[name=app].image. Only owned leaves and
+// nodes marked "." (owned as a whole) are returned.
+func flattenFieldSet(set map[string]interface{}, prefix string) []string {
+	var paths []string
+	for key, value := range set {
+		if key == "." {
+			paths = append(paths, prefix)
+			continue
+		}
+
+		path := prefix + fieldSetKey(key)
+		children, _ := value.(map[string]interface{})
+		if len(children) == 0 {
+			paths = append(paths, path)
+			continue
+		}
+		paths = append(paths, flattenFieldSet(children, path)...)
+	}
+	return paths
+}
+
+// fieldSetKey renders one FieldsV1 key: f:<field>, k:<list item keys>,
+// v:<set value> or i:<index>
+func fieldSetKey(key string) string {
+	kind, value, found := strings.Cut(key, ":")
+	if !found {
+		return "." + key
+	}
+
+	switch kind {
+	case "f":
+		return "." + value
+	case "k":
+		var keys map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &keys); err != nil {
+			return "[" + value + "]"
+		}
+		parts := make([]string, 0, len(keys))
+		for k, v := range keys {
+			parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+		}
+		sort.Strings(parts)
+		return "[" + strings.Join(parts, ",") + "]"
+	case "v":
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return "[=" + value + "]"
+		}
+		return fmt.Sprintf("[=%v]", v)
+	case "i":
+		return "[" + value + "]"
+	}
+	return "." + key
+}
@@ -0,0 +1,375 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// maxFileEntries caps how many entries a directory listing returns
+const maxFileEntries = 1000
+
+// maxUploadSize caps the request body accepted for container uploads
+const maxUploadSize = 100 << 20
+
+// fileExecTimeout bounds how long a file operation may run inside a container
+const fileExecTimeout = 30 * time.Second
+
+// FileHandler browses and transfers files inside pod containers via exec
+type FileHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewFileHandler(k8s *service.K8sManager) *FileHandler {
+	return &FileHandler{k8s: k8s}
+}
+
+type FileEntry struct {
+	Name       string `json:"name"`
+	Size       int64  `json:"size"`
+	Mode       string `json:"mode"`
+	ModTime    string `json:"modTime"`
+	IsDir      bool   `json:"isDir"`
+	IsLink     bool   `json:"isLink,omitempty"`
+	LinkTarget string `json:"linkTarget,omitempty"`
+}
+
+type FileListing struct {
+	Path      string      `json:"path"`
+	Container string      `json:"container"`
+	Entries   []FileEntry `json:"entries"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+// List returns a structured directory listing from inside a container
+func (h *FileHandler) List(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+	dir := ctx.Param("path")
+	if dir == "" {
+		dir = "/"
+	}
+	dir = path.Clean(dir)
+	if !path.IsAbs(dir) {
+		return nil, fmt.Errorf("path must be absolute")
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, fileExecTimeout)
+	defer cancel()
+
+	container, err := resolveContainer(execCtx, h.k8s, namespace, name, ctx.Param("container"))
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	// Trailing slash makes ls list the contents when the path is a symlink to a directory
+	err = execInPod(execCtx, h.k8s, namespace, name, container,
+		[]string{"ls", "-la", "--full-time", "--", strings.TrimSuffix(dir, "/") + "/"}, nil, &stdout, &stderr)
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to list %s: %s", dir, msg)
+		}
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+
+	entries, truncated := parseLsOutput(stdout.String(), maxFileEntries)
+
+	return FileListing{
+		Path:      dir,
+		Container: container,
+		Entries:   entries,
+		Truncated: truncated,
+	}, nil
+}
+
+// parseLsOutput parses `ls -la --full-time` output into file entries, skipping "." and ".."
+func parseLsOutput(output string, limit int) ([]FileEntry, bool) {
+	entries := []FileEntry{}
+	for _, line := range strings.Split(output, "\n") {
+		// mode links owner group size date time zone name...
+		fields := strings.Fields(line)
+		if len(fields) < 9 || fields[0] == "total" {
+			continue
+		}
+
+		mode := fields[0]
+		size, _ := strconv.ParseInt(fields[4], 10, 64)
+
+		// Character and block devices show "major, minor" in place of the size,
+		// usually as two fields, which shifts the rest of the line by one
+		skip := 8
+		if strings.HasPrefix(mode, "c") || strings.HasPrefix(mode, "b") {
+			size = 0
+			if strings.HasSuffix(fields[4], ",") {
+				if len(fields) < 10 {
+					continue
+				}
+				fields = append(fields[:4], fields[5:]...)
+				skip++
+			}
+		}
+
+		// The name is everything after the timezone field, preserving inner spaces
+		name := line
+		for i := 0; i < skip; i++ {
+			name = strings.TrimLeft(name, " ")
+			if idx := strings.IndexByte(name, ' '); idx >= 0 {
+				name = name[idx:]
+			}
+		}
+		name = strings.TrimLeft(name, " ")
+
+		entry := FileEntry{
+			Mode:    mode,
+			Size:    size,
+			IsDir:   strings.HasPrefix(mode, "d"),
+			IsLink:  strings.HasPrefix(mode, "l"),
+			ModTime: formatLsTime(fields[5], fields[6], fields[7]),
+		}
+
+		if entry.IsLink {
+			if idx := strings.Index(name, " -> "); idx >= 0 {
+				entry.LinkTarget = name[idx+4:]
+				name = name[:idx]
+			}
+		}
+		if name == "." || name == ".." {
+			continue
+		}
+		entry.Name = name
+
+		if len(entries) >= limit {
+			return entries, true
+		}
+		entries = append(entries, entry)
+	}
+	return entries, false
+}
+
+// formatLsTime converts the --full-time date, time and zone fields to RFC3339
+func formatLsTime(date, clock, zone string) string {
+	t, err := time.Parse("2006-01-02 15:04:05.999999999 -0700", date+" "+clock+" "+zone)
+	if err != nil {
+		return date + " " + clock
+	}
+	return t.Format(time.RFC3339)
+}
+
+// writeTracker records whether any bytes have reached the response yet
+type writeTracker struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (t *writeTracker) Write(p []byte) (int, error) {
+	t.written = true
+	return t.w.Write(p)
+}
+
+// HandleDownload streams a file (or a tar of a directory) out of a container
+func (h *FileHandler) HandleDownload(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	name := r.PathValue("name")
+	filePath := r.URL.Query().Get("path")
+
+	if filePath == "" {
+		http.Error(w, "path is required", http.StatusBadRequest)
+		return
+	}
+	filePath = path.Clean(filePath)
+	if !path.IsAbs(filePath) {
+		http.Error(w, "path must be absolute", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	container, err := resolveContainer(ctx, h.k8s, namespace, name, r.URL.Query().Get("container"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// `test -d` exits non-zero for anything that isn't a directory
+	isDir := execInPod(ctx, h.k8s, namespace, name, container, []string{"test", "-d", filePath}, nil, nil, nil) == nil
+
+	command := []string{"cat", "--", filePath}
+	fileName := path.Base(filePath)
+	contentType := "application/octet-stream"
+	if isDir {
+		command = []string{"tar", "cf", "-", "-C", filePath, "."}
+		fileName += ".tar"
+		contentType = "application/x-tar"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	var stderr bytes.Buffer
+	out := &writeTracker{w: w}
+	err = execInPod(ctx, h.k8s, namespace, name, container, command, nil, out, &stderr)
+	if err != nil && !out.written {
+		w.Header().Del("Content-Disposition")
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		http.Error(w, fmt.Sprintf("failed to read %s: %s", filePath, msg), http.StatusInternalServerError)
+	}
+}
+
+// HandleCopy streams a path out of a container as a tar, like `kubectl cp` in
+// the download direction. Unlike HandleDownload the result is always a tar, with
+// entries named from the path's base so it extracts like kubectl cp would.
+func (h *FileHandler) HandleCopy(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	name := r.PathValue("name")
+	srcPath := r.URL.Query().Get("path")
+
+	if srcPath == "" {
+		http.Error(w, "path is required", http.StatusBadRequest)
+		return
+	}
+	srcPath = path.Clean(srcPath)
+	if !path.IsAbs(srcPath) {
+		http.Error(w, "path must be absolute", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	container, err := resolveContainer(ctx, h.k8s, namespace, name, r.URL.Query().Get("container"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// tar writes an empty archive to stdout before failing on a missing path, so
+	// check it first. `ls -d` also succeeds for a dangling symlink, which tar copies.
+	var checkErr bytes.Buffer
+	if err := execInPod(ctx, h.k8s, namespace, name, container, []string{"ls", "-d", "--", srcPath}, nil, nil, &checkErr); err != nil {
+		msg := strings.TrimSpace(checkErr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		// GNU and busybox ls both report a missing path this way
+		status := http.StatusInternalServerError
+		if strings.Contains(msg, "No such file or directory") {
+			status = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("failed to copy %s: %s", srcPath, msg), status)
+		return
+	}
+
+	dir, base := path.Dir(srcPath), path.Base(srcPath)
+	fileName := base + ".tar"
+	if srcPath == "/" {
+		base, fileName = ".", "root.tar"
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	var stderr bytes.Buffer
+	out := &writeTracker{w: w}
+	err = execInPod(ctx, h.k8s, namespace, name, container, []string{"tar", "cf", "-", "-C", dir, "--", base}, nil, out, &stderr)
+	if err != nil && !out.written {
+		w.Header().Del("Content-Disposition")
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		http.Error(w, fmt.Sprintf("failed to copy %s: %s", srcPath, msg), http.StatusInternalServerError)
+	}
+}
+
+// HandleUpload writes the request body to a path inside a container.
+// A tar body (Content-Type application/x-tar) is extracted into the path as a directory.
+func (h *FileHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	name := r.PathValue("name")
+	filePath := r.URL.Query().Get("path")
+
+	if filePath == "" {
+		http.Error(w, "path is required", http.StatusBadRequest)
+		return
+	}
+	filePath = path.Clean(filePath)
+	if !path.IsAbs(filePath) {
+		http.Error(w, "path must be absolute", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	container, err := resolveContainer(ctx, h.k8s, namespace, name, r.URL.Query().Get("container"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	command := []string{"cp", "/dev/stdin", filePath}
+	if r.Header.Get("Content-Type") == "application/x-tar" {
+		command = []string{"tar", "xf", "-", "-C", filePath}
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxUploadSize)
+	defer body.Close()
+
+	var stderr bytes.Buffer
+	if err := execInPod(ctx, h.k8s, namespace, name, container, command, body, nil, &stderr); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		http.Error(w, fmt.Sprintf("failed to write %s: %s", filePath, msg), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]string{
+			"message":   fmt.Sprintf("Uploaded to %s", filePath),
+			"container": container,
+		},
+	})
+}
+
+// Middleware creates an HTTP middleware for raw file transfer requests
+func (h *FileHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Matches /api/pods/{namespace}/{name}/download, /cp and /upload
+		if strings.HasPrefix(r.URL.Path, "/api/pods/") {
+			parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/pods/"), "/")
+			if len(parts) == 3 {
+				r.SetPathValue("namespace", parts[0])
+				r.SetPathValue("name", parts[1])
+
+				if r.Method == "GET" && parts[2] == "download" {
+					h.HandleDownload(w, r)
+					return
+				}
+				if r.Method == "GET" && parts[2] == "cp" {
+					h.HandleCopy(w, r)
+					return
+				}
+				if r.Method == "POST" && parts[2] == "upload" {
+					h.HandleUpload(w, r)
+					return
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
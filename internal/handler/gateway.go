@@ -0,0 +1,455 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// gatewayAPIGroup is the Gateway API group. Its resources are CRDs, so they may not be installed.
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+
+// gatewayAPIVersions are tried in order; clusters on releases before v1.0 only serve v1beta1
+var gatewayAPIVersions = []string{"v1", "v1beta1"}
+
+// GatewayHandler reads Gateway API resources through the dynamic client
+type GatewayHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewGatewayHandler(k8s *service.K8sManager) *GatewayHandler {
+	return &GatewayHandler{k8s: k8s}
+}
+
+type GatewayInfo struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Class      string            `json:"class"`
+	Addresses  []string          `json:"addresses"`
+	Listeners  []GatewayListener `json:"listeners"`
+	Programmed bool              `json:"programmed"`
+	Message    string            `json:"message,omitempty"` // Why the gateway isn't programmed
+	Age        string            `json:"age"`
+}
+
+type GatewayListener struct {
+	Name           string   `json:"name"`
+	Protocol       string   `json:"protocol"`
+	Port           int32    `json:"port"`
+	Hostname       string   `json:"hostname,omitempty"`
+	TLSMode        string   `json:"tlsMode,omitempty"`
+	Certificates   []string `json:"certificates,omitempty"` // namespace/name of referenced secrets
+	AllowedRoutes  string   `json:"allowedRoutes"`          // Namespaces routes may attach from: Same, All or Selector
+	AttachedRoutes int32    `json:"attachedRoutes"`
+	Programmed     bool     `json:"programmed"`
+}
+
+type HTTPRouteInfo struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Hostnames []string          `json:"hostnames"`
+	Parents   []HTTPRouteParent `json:"parents"`
+	Rules     []HTTPRouteRule   `json:"rules"`
+	Age       string            `json:"age"`
+}
+
+// HTTPRouteParent is a gateway the route attaches to, with the status that gateway reported
+type HTTPRouteParent struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	SectionName string `json:"sectionName,omitempty"` // Listener name, if the route targets one
+	Accepted    bool   `json:"accepted"`
+	Message     string `json:"message,omitempty"` // Why the route wasn't accepted or its refs didn't resolve
+}
+
+type HTTPRouteRule struct {
+	Matches     []string         `json:"matches"` // e.g. "GET PathPrefix /api header:x-env=canary"
+	Filters     []string         `json:"filters,omitempty"`
+	BackendRefs []HTTPBackendRef `json:"backendRefs"`
+}
+
+type HTTPBackendRef struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Port      int32  `json:"port,omitempty"`
+	Weight    int32  `json:"weight"`
+}
+
+// The parts of the Gateway API objects kubeui shows, decoded from unstructured
+
+type gatewayCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+type gatewayObject struct {
+	Spec struct {
+		GatewayClassName string `json:"gatewayClassName"`
+		Listeners        []struct {
+			Name     string `json:"name"`
+			Hostname string `json:"hostname"`
+			Port     int32  `json:"port"`
+			Protocol string `json:"protocol"`
+			TLS      *struct {
+				Mode            string `json:"mode"`
+				CertificateRefs []struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"certificateRefs"`
+			} `json:"tls"`
+			AllowedRoutes *struct {
+				Namespaces *struct {
+					From string `json:"from"`
+				} `json:"namespaces"`
+			} `json:"allowedRoutes"`
+		} `json:"listeners"`
+	} `json:"spec"`
+	Status struct {
+		Addresses []struct {
+			Value string `json:"value"`
+		} `json:"addresses"`
+		Conditions []gatewayCondition `json:"conditions"`
+		Listeners  []struct {
+			Name           string             `json:"name"`
+			AttachedRoutes int32              `json:"attachedRoutes"`
+			Conditions     []gatewayCondition `json:"conditions"`
+		} `json:"listeners"`
+	} `json:"status"`
+}
+
+type gatewayParentRef struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	SectionName string `json:"sectionName"`
+}
+
+type httpRouteObject struct {
+	Spec struct {
+		ParentRefs []gatewayParentRef `json:"parentRefs"`
+		Hostnames  []string           `json:"hostnames"`
+		Rules      []struct {
+			Matches []struct {
+				Path *struct {
+					Type  string `json:"type"`
+					Value string `json:"value"`
+				} `json:"path"`
+				Method  string `json:"method"`
+				Headers []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"headers"`
+				QueryParams []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"queryParams"`
+			} `json:"matches"`
+			Filters []struct {
+				Type string `json:"type"`
+			} `json:"filters"`
+			BackendRefs []struct {
+				Kind      string `json:"kind"`
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+				Port      int32  `json:"port"`
+				Weight    *int32 `json:"weight"`
+			} `json:"backendRefs"`
+		} `json:"rules"`
+	} `json:"spec"`
+	Status struct {
+		Parents []struct {
+			ParentRef  gatewayParentRef   `json:"parentRef"`
+			Conditions []gatewayCondition `json:"conditions"`
+		} `json:"parents"`
+	} `json:"status"`
+}
+
+// ListGateways returns Gateways with their listeners and addresses, or an
+// empty list when the Gateway API isn't installed
+func (h *GatewayHandler) ListGateways(ctx *gofr.Context) (interface{}, error) {
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	items, err := h.list(ctx, "gateways", namespace, listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []GatewayInfo{}
+	for i := range items {
+		info, err := gatewayToInfo(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// GetGateway returns a single Gateway
+func (h *GatewayHandler) GetGateway(ctx *gofr.Context) (interface{}, error) {
+	obj, err := h.get(ctx, "gateways", ctx.PathParam("namespace"), ctx.PathParam("name"))
+	if err != nil {
+		return lookupError(err)
+	}
+	return gatewayToInfo(obj)
+}
+
+// ListHTTPRoutes returns HTTPRoutes with their rules and backends, or an empty
+// list when the Gateway API isn't installed
+func (h *GatewayHandler) ListHTTPRoutes(ctx *gofr.Context) (interface{}, error) {
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	items, err := h.list(ctx, "httproutes", namespace, listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []HTTPRouteInfo{}
+	for i := range items {
+		info, err := httpRouteToInfo(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// GetHTTPRoute returns a single HTTPRoute
+func (h *GatewayHandler) GetHTTPRoute(ctx *gofr.Context) (interface{}, error) {
+	obj, err := h.get(ctx, "httproutes", ctx.PathParam("namespace"), ctx.PathParam("name"))
+	if err != nil {
+		return lookupError(err)
+	}
+	return httpRouteToInfo(obj)
+}
+
+// list lists a Gateway API resource at the newest served version. A 404 for
+// every version means the CRD isn't installed, which is reported as no items.
+func (h *GatewayHandler) list(ctx context.Context, resource, namespace string, opts metav1.ListOptions) ([]unstructured.Unstructured, error) {
+	for _, version := range gatewayAPIVersions {
+		gvr := schema.GroupVersionResource{Group: gatewayAPIGroup, Version: version, Resource: resource}
+		client, err := resourceClient(h.k8s, gvr, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		list, err := client.List(ctx, opts)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+	return nil, nil
+}
+
+// get fetches a Gateway API object, trying each version in turn
+func (h *GatewayHandler) get(ctx context.Context, resource, namespace, name string) (*unstructured.Unstructured, error) {
+	var lastErr error
+	for _, version := range gatewayAPIVersions {
+		gvr := schema.GroupVersionResource{Group: gatewayAPIGroup, Version: version, Resource: resource}
+		client, err := resourceClient(h.k8s, gvr, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return obj, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func gatewayToInfo(obj *unstructured.Unstructured) (GatewayInfo, error) {
+	var gw gatewayObject
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &gw); err != nil {
+		return GatewayInfo{}, fmt.Errorf("failed to decode gateway %s: %w", obj.GetName(), err)
+	}
+
+	info := GatewayInfo{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Class:     gw.Spec.GatewayClassName,
+		Addresses: []string{},
+		Listeners: []GatewayListener{},
+		Age:       formatAge(obj.GetCreationTimestamp().Time),
+	}
+	for _, addr := range gw.Status.Addresses {
+		info.Addresses = append(info.Addresses, addr.Value)
+	}
+	info.Programmed, info.Message = gatewayConditionStatus(gw.Status.Conditions, "Programmed")
+
+	for _, l := range gw.Spec.Listeners {
+		listener := GatewayListener{
+			Name:          l.Name,
+			Protocol:      l.Protocol,
+			Port:          l.Port,
+			Hostname:      l.Hostname,
+			AllowedRoutes: "Same",
+		}
+		if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil && l.AllowedRoutes.Namespaces.From != "" {
+			listener.AllowedRoutes = l.AllowedRoutes.Namespaces.From
+		}
+		if l.TLS != nil {
+			listener.TLSMode = l.TLS.Mode
+			if listener.TLSMode == "" {
+				listener.TLSMode = "Terminate"
+			}
+			for _, ref := range l.TLS.CertificateRefs {
+				ns := ref.Namespace
+				if ns == "" {
+					ns = info.Namespace
+				}
+				listener.Certificates = append(listener.Certificates, ns+"/"+ref.Name)
+			}
+		}
+		for _, s := range gw.Status.Listeners {
+			if s.Name == l.Name {
+				listener.AttachedRoutes = s.AttachedRoutes
+				listener.Programmed, _ = gatewayConditionStatus(s.Conditions, "Programmed")
+			}
+		}
+		info.Listeners = append(info.Listeners, listener)
+	}
+
+	return info, nil
+}
+
+func httpRouteToInfo(obj *unstructured.Unstructured) (HTTPRouteInfo, error) {
+	var route httpRouteObject
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &route); err != nil {
+		return HTTPRouteInfo{}, fmt.Errorf("failed to decode httproute %s: %w", obj.GetName(), err)
+	}
+
+	namespace := obj.GetNamespace()
+	info := HTTPRouteInfo{
+		Name:      obj.GetName(),
+		Namespace: namespace,
+		Hostnames: route.Spec.Hostnames,
+		Parents:   []HTTPRouteParent{},
+		Rules:     []HTTPRouteRule{},
+		Age:       formatAge(obj.GetCreationTimestamp().Time),
+	}
+	if len(info.Hostnames) == 0 {
+		info.Hostnames = []string{"*"}
+	}
+
+	for _, ref := range route.Spec.ParentRefs {
+		parent := HTTPRouteParent{Kind: ref.Kind, Name: ref.Name, Namespace: ref.Namespace, SectionName: ref.SectionName}
+		if parent.Kind == "" {
+			parent.Kind = "Gateway"
+		}
+		if parent.Namespace == "" {
+			parent.Namespace = namespace
+		}
+		for _, s := range route.Status.Parents {
+			// Controllers may fill in the namespace the spec left to default
+			statusNamespace := s.ParentRef.Namespace
+			if statusNamespace == "" {
+				statusNamespace = namespace
+			}
+			if s.ParentRef.Name != ref.Name || statusNamespace != parent.Namespace || s.ParentRef.SectionName != ref.SectionName {
+				continue
+			}
+			var message string
+			parent.Accepted, message = gatewayConditionStatus(s.Conditions, "Accepted")
+			if resolved, msg := gatewayConditionStatus(s.Conditions, "ResolvedRefs"); message == "" && !resolved {
+				message = msg
+			}
+			parent.Message = message
+		}
+		info.Parents = append(info.Parents, parent)
+	}
+
+	for _, r := range route.Spec.Rules {
+		rule := HTTPRouteRule{Matches: []string{}, BackendRefs: []HTTPBackendRef{}}
+
+		for _, m := range r.Matches {
+			var parts []string
+			if m.Method != "" {
+				parts = append(parts, m.Method)
+			}
+			pathType, pathValue := "PathPrefix", "/"
+			if m.Path != nil {
+				if m.Path.Type != "" {
+					pathType = m.Path.Type
+				}
+				if m.Path.Value != "" {
+					pathValue = m.Path.Value
+				}
+			}
+			parts = append(parts, pathType, pathValue)
+			for _, hdr := range m.Headers {
+				parts = append(parts, fmt.Sprintf("header:%s=%s", hdr.Name, hdr.Value))
+			}
+			for _, q := range m.QueryParams {
+				parts = append(parts, fmt.Sprintf("query:%s=%s", q.Name, q.Value))
+			}
+			rule.Matches = append(rule.Matches, strings.Join(parts, " "))
+		}
+		// A rule without matches matches every request
+		if len(rule.Matches) == 0 {
+			rule.Matches = append(rule.Matches, "PathPrefix /")
+		}
+
+		for _, f := range r.Filters {
+			rule.Filters = append(rule.Filters, f.Type)
+		}
+
+		for _, b := range r.BackendRefs {
+			backend := HTTPBackendRef{Kind: b.Kind, Name: b.Name, Namespace: b.Namespace, Port: b.Port, Weight: 1}
+			if backend.Kind == "" {
+				backend.Kind = "Service"
+			}
+			if backend.Namespace == "" {
+				backend.Namespace = namespace
+			}
+			if b.Weight != nil {
+				backend.Weight = *b.Weight
+			}
+			rule.BackendRefs = append(rule.BackendRefs, backend)
+		}
+
+		info.Rules = append(info.Rules, rule)
+	}
+
+	return info, nil
+}
+
+// gatewayConditionStatus reports whether a condition is True, with its message when it isn't
+func gatewayConditionStatus(conditions []gatewayCondition, conditionType string) (bool, string) {
+	for _, c := range conditions {
+		if c.Type != conditionType {
+			continue
+		}
+		if c.Status == "True" {
+			return true, ""
+		}
+		if c.Message != "" {
+			return false, c.Message
+		}
+		return false, c.Reason
+	}
+	return false, ""
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// maxHistoryEntries is how many recently viewed resources are remembered
+const maxHistoryEntries = 50
+
+// historyTypes are the resource types whose detail endpoints are recorded
+var historyTypes = map[string]string{
+	"pods":         "Pod",
+	"deployments":  "Deployment",
+	"services":     "Service",
+	"configmaps":   "ConfigMap",
+	"secrets":      "Secret",
+	"jobs":         "Job",
+	"cronjobs":     "CronJob",
+	"daemonsets":   "DaemonSet",
+	"statefulsets": "StatefulSet",
+	"replicasets":  "ReplicaSet",
+	"hpas":         "HorizontalPodAutoscaler",
+}
+
+// HistoryHandler remembers recently viewed resources in memory
+type HistoryHandler struct {
+	k8s     *service.K8sManager
+	entries []HistoryEntry // newest first
+	mu      sync.RWMutex
+}
+
+type HistoryEntry struct {
+	Type      string `json:"type"` // API path segment, e.g. "pods"
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Context   string `json:"context"`
+	ViewedAt  string `json:"viewedAt"`
+}
+
+func NewHistoryHandler(k8s *service.K8sManager) *HistoryHandler {
+	return &HistoryHandler{k8s: k8s}
+}
+
+// List returns recently viewed resources, newest first
+func (h *HistoryHandler) List(ctx *gofr.Context) (interface{}, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := make([]HistoryEntry, len(h.entries))
+	copy(result, h.entries)
+	return result, nil
+}
+
+// record moves the resource to the front of the history, adding it if new
+func (h *HistoryHandler) record(entry HistoryEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, e := range h.entries {
+		if e.Type == entry.Type && e.Namespace == entry.Namespace && e.Name == entry.Name && e.Context == entry.Context {
+			h.entries = append(h.entries[:i], h.entries[i+1:]...)
+			break
+		}
+	}
+
+	h.entries = append([]HistoryEntry{entry}, h.entries...)
+	if len(h.entries) > maxHistoryEntries {
+		h.entries = h.entries[:maxHistoryEntries]
+	}
+}
+
+// statusRecorder captures the response status so only successful views are recorded
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Middleware records successful GETs of /api/{type}/{namespace}/{name} detail endpoints
+func (h *HistoryHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/"), "/")
+		kind, tracked := historyTypes[parts[0]]
+		if !tracked || len(parts) != 3 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if rec.status < http.StatusBadRequest {
+			h.record(HistoryEntry{
+				Type:      parts[0],
+				Kind:      kind,
+				Namespace: parts[1],
+				Name:      parts[2],
+				Context:   h.k8s.CurrentContext(),
+				ViewedAt:  time.Now().Format(time.RFC3339),
+			})
+		}
+	})
+}
@@ -2,10 +2,15 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"gofr.dev/pkg/gofr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "sigs.k8s.io/yaml"
 
 	"github.com/opengittr/kubeui/internal/service"
 )
@@ -61,14 +66,14 @@ type HPAScalingRules struct {
 }
 
 func (h *HPAHandler) List(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.Background(), metav1.ListOptions{})
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -147,7 +152,7 @@ func (h *HPAHandler) Get(ctx *gofr.Context) (interface{}, error) {
 
 	hpa, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	// Get reference
@@ -209,16 +214,7 @@ func (h *HPAHandler) Get(ctx *gofr.Context) (interface{}, error) {
 		minPods = *hpa.Spec.MinReplicas
 	}
 
-	// Get conditions
-	var conditions []HPACondition
-	for _, c := range hpa.Status.Conditions {
-		conditions = append(conditions, HPACondition{
-			Type:    string(c.Type),
-			Status:  string(c.Status),
-			Reason:  c.Reason,
-			Message: c.Message,
-		})
-	}
+	conditions := hpaConditions(hpa)
 
 	// Get scaling behavior
 	var scaleUpBehavior, scaleDownBehavior *HPAScalingRules
@@ -268,6 +264,106 @@ func (h *HPAHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	}, nil
 }
 
+func hpaConditions(hpa *autoscalingv2.HorizontalPodAutoscaler) []HPACondition {
+	var conditions []HPACondition
+	for _, c := range hpa.Status.Conditions {
+		conditions = append(conditions, HPACondition{
+			Type:    string(c.Type),
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+	}
+	return conditions
+}
+
+// HPAActivity is an HPA's scaling position between its bounds
+type HPAActivity struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	Reference       string `json:"reference"`
+	MinReplicas     int32  `json:"minReplicas"`
+	MaxReplicas     int32  `json:"maxReplicas"`
+	CurrentReplicas int32  `json:"currentReplicas"`
+	DesiredReplicas int32  `json:"desiredReplicas"`
+	AtMax           bool   `json:"atMax"`
+	AtMin           bool   `json:"atMin"`
+	// Why scaling is held back, from the first failing condition: ScalingLimited
+	// True, or AbleToScale or ScalingActive False
+	LimitingCondition *HPACondition `json:"limitingCondition,omitempty"`
+	LastScaleTime     string        `json:"lastScaleTime,omitempty"`
+}
+
+// Activity lists HPAs with their current and desired replicas and whether they
+// sit at a bound, maxed-out ones first, for spotting HPAs that can't scale further
+func (h *HPAHandler) Activity(ctx *gofr.Context) (interface{}, error) {
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []HPAActivity{}
+	for i := range hpas.Items {
+		hpa := &hpas.Items[i]
+
+		minReplicas := int32(1)
+		if hpa.Spec.MinReplicas != nil {
+			minReplicas = *hpa.Spec.MinReplicas
+		}
+
+		activity := HPAActivity{
+			Name:            hpa.Name,
+			Namespace:       hpa.Namespace,
+			Reference:       fmt.Sprintf("%s/%s", hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name),
+			MinReplicas:     minReplicas,
+			MaxReplicas:     hpa.Spec.MaxReplicas,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+			AtMax:           hpa.Status.CurrentReplicas >= hpa.Spec.MaxReplicas,
+			AtMin:           hpa.Status.CurrentReplicas <= minReplicas,
+		}
+		if hpa.Status.LastScaleTime != nil {
+			activity.LastScaleTime = formatAge(hpa.Status.LastScaleTime.Time)
+		}
+
+		for _, c := range hpaConditions(hpa) {
+			limiting := false
+			switch autoscalingv2.HorizontalPodAutoscalerConditionType(c.Type) {
+			case autoscalingv2.ScalingLimited:
+				limiting = c.Status == string(corev1.ConditionTrue)
+			case autoscalingv2.AbleToScale, autoscalingv2.ScalingActive:
+				limiting = c.Status == string(corev1.ConditionFalse)
+			}
+			if limiting {
+				condition := c
+				activity.LimitingCondition = &condition
+				break
+			}
+		}
+
+		result = append(result, activity)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].AtMax != result[j].AtMax {
+			return result[i].AtMax
+		}
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
 // Events returns events for a specific HPA
 func (h *HPAHandler) Events(ctx *gofr.Context) (interface{}, error) {
 	namespace := ctx.PathParam("namespace")
@@ -279,9 +375,9 @@ func (h *HPAHandler) Events(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=HorizontalPodAutoscaler", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -314,3 +410,77 @@ func (h *HPAHandler) Events(ctx *gofr.Context) (interface{}, error) {
 
 	return result, nil
 }
+
+// Create creates an HPA from an autoscaling/v2 manifest given as YAML or JSON
+// in the yaml field. The manifest's namespace is used if set, otherwise the
+// namespace param or the session's namespace.
+func (h *HPAHandler) Create(ctx *gofr.Context) (interface{}, error) {
+	var req struct {
+		YAML string `json:"yaml"`
+	}
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, so both formats convert the same way
+	jsonBytes, err := k8syaml.YAMLToJSON([]byte(req.YAML))
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	var hpa autoscalingv2.HorizontalPodAutoscaler
+	if err := json.Unmarshal(jsonBytes, &hpa); err != nil {
+		return nil, fmt.Errorf("invalid HorizontalPodAutoscaler: %w", err)
+	}
+
+	if hpa.Kind != "" && hpa.Kind != "HorizontalPodAutoscaler" {
+		return nil, fmt.Errorf("expected kind HorizontalPodAutoscaler, got %s", hpa.Kind)
+	}
+	if hpa.APIVersion != "" && hpa.APIVersion != autoscalingv2.SchemeGroupVersion.String() {
+		return nil, fmt.Errorf("expected apiVersion %s, got %s", autoscalingv2.SchemeGroupVersion, hpa.APIVersion)
+	}
+	if hpa.Name == "" && hpa.GenerateName == "" {
+		return nil, fmt.Errorf("metadata.name is required")
+	}
+
+	if hpa.Namespace == "" {
+		scope := resolveNamespace(ctx, h.k8s)
+		if scope.AllNamespaces {
+			return nil, fmt.Errorf("metadata.namespace is required when no namespace is selected")
+		}
+		hpa.Namespace = scope.Namespace
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.AutoscalingV2().HorizontalPodAutoscalers(hpa.Namespace).Create(context.Background(), &hpa, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"message":   fmt.Sprintf("HPA %s created", created.Name),
+		"name":      created.Name,
+		"namespace": created.Namespace,
+	}, nil
+}
+
+// Delete deletes an HPA; the target workload keeps its current replica count
+func (h *HPAHandler) Delete(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"message": fmt.Sprintf("HPA %s deleted", name)}, nil
+}
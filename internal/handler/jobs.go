@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"gofr.dev/pkg/gofr"
+	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/opengittr/kubeui/internal/service"
@@ -81,24 +82,28 @@ type CronJobInfo struct {
 }
 
 func (h *JobHandler) ListJobs(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	jobs, err := client.BatchV1().Jobs(namespace).List(context.Background(), metav1.ListOptions{})
+	jobs, err := client.BatchV1().Jobs(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
 
 	var result []JobInfo
 	for _, j := range jobs.Items {
-		completions := fmt.Sprintf("%d/%d", j.Status.Succeeded, *j.Spec.Completions)
+		// Completions is nil for parallelism-only work-queue jobs
+		completions := int32(1)
+		if j.Spec.Completions != nil {
+			completions = *j.Spec.Completions
+		}
 
 		status := "Running"
-		if j.Status.Succeeded > 0 && j.Status.Succeeded == *j.Spec.Completions {
+		if j.Status.Succeeded > 0 && j.Status.Succeeded == completions {
 			status = "Complete"
 		} else if j.Status.Failed > 0 {
 			status = "Failed"
@@ -113,7 +118,7 @@ func (h *JobHandler) ListJobs(ctx *gofr.Context) (interface{}, error) {
 		result = append(result, JobInfo{
 			Name:        j.Name,
 			Namespace:   j.Namespace,
-			Completions: completions,
+			Completions: fmt.Sprintf("%d/%d", j.Status.Succeeded, completions),
 			Duration:    duration,
 			Age:         formatAge(j.CreationTimestamp.Time),
 			Status:      status,
@@ -124,14 +129,14 @@ func (h *JobHandler) ListJobs(ctx *gofr.Context) (interface{}, error) {
 }
 
 func (h *JobHandler) ListCronJobs(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	cronJobs, err := client.BatchV1().CronJobs(namespace).List(context.Background(), metav1.ListOptions{})
+	cronJobs, err := client.BatchV1().CronJobs(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -195,6 +200,54 @@ func (h *JobHandler) DeleteCronJob(ctx *gofr.Context) (interface{}, error) {
 	return map[string]string{"message": fmt.Sprintf("CronJob %s deleted", name)}, nil
 }
 
+// TriggerCronJob starts a job from the CronJob's template now, like kubectl
+// create job --from=cronjob. The job is owned by the CronJob so it shows up in
+// its history and is cleaned up with it.
+func (h *JobHandler) TriggerCronJob(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cj, err := client.BatchV1().CronJobs(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return lookupError(err)
+	}
+
+	annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	for k, v := range cj.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			// The apiserver adds a random suffix, trimming the base so the name stays
+			// valid as the batch.kubernetes.io/job-name label value
+			GenerateName: name + "-manual-",
+			Namespace:    namespace,
+			Labels:       cj.Spec.JobTemplate.Labels,
+			Annotations:  annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cj, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cj.Spec.JobTemplate.Spec,
+	}
+
+	created, err := client.BatchV1().Jobs(namespace).Create(context.Background(), job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"message": fmt.Sprintf("Job %s created from CronJob %s", created.Name, name),
+		"job":     created.Name,
+	}, nil
+}
+
 // GetJob returns details of a specific job
 func (h *JobHandler) GetJob(ctx *gofr.Context) (interface{}, error) {
 	namespace := ctx.PathParam("namespace")
@@ -207,7 +260,7 @@ func (h *JobHandler) GetJob(ctx *gofr.Context) (interface{}, error) {
 
 	j, err := client.BatchV1().Jobs(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	completions := int32(1)
@@ -300,9 +353,9 @@ func (h *JobHandler) fetchJobRunningContainers(namespace, jobName string) []JobR
 		return nil
 	}
 
-	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		LabelSelector: labelSelector,
-	})
+	}))
 	if err != nil {
 		return nil
 	}
@@ -311,9 +364,9 @@ func (h *JobHandler) fetchJobRunningContainers(namespace, jobName string) []JobR
 	metricsMap := make(map[string]map[string]ContainerResource)
 	mc, err := h.k8s.GetMetricsClient()
 	if err == nil {
-		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), metav1.ListOptions{
+		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 			LabelSelector: labelSelector,
-		})
+		}))
 		if err == nil {
 			for _, pm := range podMetrics.Items {
 				if metricsMap[pm.Name] == nil {
@@ -390,9 +443,9 @@ func (h *JobHandler) JobEvents(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=Job", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -438,7 +491,7 @@ func (h *JobHandler) GetCronJob(ctx *gofr.Context) (interface{}, error) {
 
 	cj, err := client.BatchV1().CronJobs(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	lastSchedule := ""
@@ -512,9 +565,9 @@ func (h *JobHandler) CronJobEvents(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=CronJob", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -559,7 +612,7 @@ func (h *JobHandler) CronJobJobs(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	// Get jobs owned by this cronjob
-	jobs, err := client.BatchV1().Jobs(namespace).List(context.Background(), metav1.ListOptions{})
+	jobs, err := client.BatchV1().Jobs(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
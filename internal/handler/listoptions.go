@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"gofr.dev/pkg/gofr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// listTimeoutSeconds bounds every list call on the apiserver side so a degraded
+// cluster returns a timeout error instead of hanging
+var listTimeoutSeconds int64 = 30
+
+// SetListTimeout sets the apiserver-side timeout for list calls. Zero disables it.
+func SetListTimeout(seconds int64) {
+	listTimeoutSeconds = seconds
+}
+
+// listOptions returns opts with the configured list timeout applied
+func listOptions(opts metav1.ListOptions) metav1.ListOptions {
+	if listTimeoutSeconds > 0 {
+		timeout := listTimeoutSeconds
+		opts.TimeoutSeconds = &timeout
+	}
+	return opts
+}
+
+// listOptionsFor returns listOptions(opts) for a list endpoint. Reads are served
+// from the apiserver's watch cache (resourceVersion=0) unless the request asks
+// for consistent=true, which reads from etcd.
+func listOptionsFor(ctx *gofr.Context, opts metav1.ListOptions) metav1.ListOptions {
+	return cachedListOptions(opts, ctx.Param("consistent") == "true")
+}
+
+// cachedListOptions applies resourceVersion=0 unless consistent is set. Paged
+// lists are left alone since the watch cache may ignore the limit.
+func cachedListOptions(opts metav1.ListOptions, consistent bool) metav1.ListOptions {
+	if !consistent && opts.ResourceVersion == "" && opts.Limit == 0 {
+		opts.ResourceVersion = "0"
+	}
+	return listOptions(opts)
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxArchiveLogBytes caps each container log in an archive
+const maxArchiveLogBytes = 100 << 20
+
+// HandleArchive streams a tar.gz of the logs of every container in a
+// deployment's pods, one <pod>/<container>.log file each. With ?previous=true
+// the previous instance of restarted containers is added as .previous.log, and
+// ?tail limits each log to its last lines. Logs that can't be read are listed
+// in errors.txt at the end of the archive.
+func (h *LogStreamHandler) HandleArchive(w http.ResponseWriter, r *http.Request, namespace, name string) {
+	ctx := r.Context()
+	previous := r.URL.Query().Get("previous") == "true"
+
+	var tailLines *int64
+	if tailParam := r.URL.Query().Get("tail"); tailParam != "" {
+		n, err := strconv.ParseInt(tailParam, 10, 64)
+		if err != nil || n <= 0 {
+			http.Error(w, "tail must be a positive number", http.StatusBadRequest)
+			return
+		}
+		tailLines = &n
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		http.Error(w, fmt.Sprintf("deployment %s/%s not found", namespace, name), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, listOptions(metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	}))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(pods.Items) == 0 {
+		http.Error(w, fmt.Sprintf("deployment %s has no pods", name), http.StatusNotFound)
+		return
+	}
+
+	// Large logs can take longer than the request timeout to copy
+	streamClient, err := h.k8s.GetStreamingClient()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	archiveName := fmt.Sprintf("%s-logs-%s", name, time.Now().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archiveName+".tar.gz"))
+
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	var failures []string
+	for _, pod := range pods.Items {
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			if ctx.Err() != nil {
+				return
+			}
+
+			file := fmt.Sprintf("%s/%s/%s.log", archiveName, pod.Name, container.Name)
+			opts := &corev1.PodLogOptions{Container: container.Name, TailLines: tailLines}
+			if err := addLogToArchive(ctx, tw, streamClient, namespace, pod.Name, file, opts); err != nil {
+				failures = append(failures, fmt.Sprintf("%s/%s: %v", pod.Name, container.Name, err))
+			}
+
+			if previous && restartCount(&pod, container.Name) > 0 {
+				file = fmt.Sprintf("%s/%s/%s.previous.log", archiveName, pod.Name, container.Name)
+				opts := &corev1.PodLogOptions{Container: container.Name, TailLines: tailLines, Previous: true}
+				if err := addLogToArchive(ctx, tw, streamClient, namespace, pod.Name, file, opts); err != nil {
+					failures = append(failures, fmt.Sprintf("%s/%s (previous): %v", pod.Name, container.Name, err))
+				}
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		report := strings.Join(failures, "\n") + "\n"
+		tw.WriteHeader(&tar.Header{Name: archiveName + "/errors.txt", Mode: 0o644, Size: int64(len(report)), ModTime: time.Now()})
+		io.WriteString(tw, report)
+	}
+}
+
+// addLogToArchive reads a container log into a temp file, since tar needs the
+// size up front, then copies it into the archive. Memory stays flat however
+// large the logs are.
+func addLogToArchive(ctx context.Context, tw *tar.Writer, client kubernetes.Interface, namespace, pod, file string, opts *corev1.PodLogOptions) error {
+	limit := int64(maxArchiveLogBytes)
+	opts.LimitBytes = &limit
+
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	tmp, err := os.CreateTemp("", "kubeui-log-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, stream)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{Name: file, Mode: 0o644, Size: size, ModTime: time.Now()}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, tmp)
+	return err
+}
+
+func restartCount(pod *corev1.Pod, container string) int32 {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.Name == container {
+			return cs.RestartCount
+		}
+	}
+	return 0
+}
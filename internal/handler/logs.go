@@ -0,0 +1,383 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// maxLogStreams caps how many container log streams one selector request may open
+const maxLogStreams = 50
+
+const (
+	// maxLogFlushInterval caps ?flush so batched lines still arrive promptly
+	maxLogFlushInterval = 5 * time.Second
+	// maxLogBatch sends a batch early once it holds this many lines
+	maxLogBatch = 500
+)
+
+// LogStreamTruncation is sent as a "warning" message when a selector matches
+// more than maxLogStreams containers and the rest aren't followed
+type LogStreamTruncation struct {
+	Message           string `json:"message"`
+	Streams           int    `json:"streams"`
+	SkippedContainers int    `json:"skippedContainers"`
+	SkippedPods       int    `json:"skippedPods"` // Pods none of whose containers are followed
+}
+
+// LogStreamHandler merges logs from every container matching a label selector into one SSE stream
+type LogStreamHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewLogStreamHandler(k8s *service.K8sManager) *LogStreamHandler {
+	return &LogStreamHandler{k8s: k8s}
+}
+
+// LogLine is a single log line from one container
+type LogLine struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Line      string `json:"line"` // Prefixed with "pod/container "
+}
+
+// Middleware serves GET /api/logs?namespace=x&selector=app=y&tail=100 and
+// GET /api/pods/{namespace}/{name}/logs/stream as SSE streams, and
+// GET /api/deployments/{namespace}/{name}/logs/archive as a tar.gz download
+func (h *LogStreamHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.URL.Path == "/api/logs" {
+			h.HandleStream(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/pods/") {
+			parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/pods/"), "/")
+			if len(parts) == 4 && parts[2] == "logs" && parts[3] == "stream" {
+				h.HandlePodStream(w, r, parts[0], parts[1])
+				return
+			}
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/deployments/") {
+			parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/deployments/"), "/")
+			if len(parts) == 4 && parts[2] == "logs" && parts[3] == "archive" {
+				h.HandleArchive(w, r, parts[0], parts[1])
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleStream follows the logs of every container in the pods matching the selector.
+// With ?flush=200ms, lines are coalesced into one "logs" message per interval
+// instead of one "log" message per line, so chatty containers don't flood slow clients.
+// At most maxLogStreams containers are followed; a "warning" message says how many were skipped.
+func (h *LogStreamHandler) HandleStream(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	selector := r.URL.Query().Get("selector")
+	if namespace == "" || selector == "" {
+		http.Error(w, "namespace and selector are required", http.StatusBadRequest)
+		return
+	}
+
+	tailLines := int64(100)
+	if tailParam := r.URL.Query().Get("tail"); tailParam != "" {
+		if n, err := strconv.ParseInt(tailParam, 10, 64); err == nil {
+			tailLines = n
+		}
+	}
+
+	var flushInterval time.Duration
+	if flushParam := r.URL.Query().Get("flush"); flushParam != "" {
+		d, err := time.ParseDuration(flushParam)
+		if err != nil || d < 0 || d > maxLogFlushInterval {
+			http.Error(w, fmt.Sprintf("flush must be a duration between 0 and %s", maxLogFlushInterval), http.StatusBadRequest)
+			return
+		}
+		flushInterval = d
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(r.Context(), listOptions(metav1.ListOptions{LabelSelector: selector}))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(pods.Items) == 0 {
+		http.Error(w, fmt.Sprintf("no pods match selector %q", selector), http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// All streams feed one channel so only this goroutine writes to the response
+	messages := make(chan SSEMessage)
+	var wg sync.WaitGroup
+
+	// Tell the client up front when the selector matches more containers than are followed
+	if truncation, ok := logStreamTruncation(pods.Items); ok {
+		sendSSEMessage(w, flusher, SSEMessage{Type: "warning", Resource: "logs", Namespace: namespace, Data: truncation})
+	}
+
+	streams := 0
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if streams == maxLogStreams {
+				break
+			}
+			streams++
+
+			wg.Add(1)
+			go func(pod, container string) {
+				defer wg.Done()
+				h.followContainer(ctx, namespace, pod, corev1.PodLogOptions{Container: container, TailLines: &tailLines}, messages)
+			}(pod.Name, container.Name)
+		}
+	}
+
+	// Close the channel once every stream has ended
+	go func() {
+		wg.Wait()
+		close(messages)
+	}()
+
+	if flushInterval == 0 {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				sendSSEMessage(w, flusher, msg)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var batch []LogLine
+	flushBatch := func() {
+		if len(batch) == 0 {
+			return
+		}
+		sendSSEMessage(w, flusher, SSEMessage{Type: "logs", Resource: "logs", Namespace: namespace, Data: batch})
+		batch = nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			flushBatch()
+		case msg, ok := <-messages:
+			if !ok {
+				flushBatch()
+				return
+			}
+			line, isLine := msg.Data.(LogLine)
+			if !isLine {
+				// Errors go out immediately, after the lines that preceded them
+				flushBatch()
+				sendSSEMessage(w, flusher, msg)
+				continue
+			}
+			batch = append(batch, line)
+			if len(batch) >= maxLogBatch {
+				flushBatch()
+			}
+		}
+	}
+}
+
+// logStreamTruncation reports which of the pods' containers fall past
+// maxLogStreams, in the order HandleStream opens streams
+func logStreamTruncation(pods []corev1.Pod) (LogStreamTruncation, bool) {
+	var t LogStreamTruncation
+	for _, pod := range pods {
+		skipped := 0
+		for range pod.Spec.Containers {
+			if t.Streams < maxLogStreams {
+				t.Streams++
+			} else {
+				skipped++
+			}
+		}
+		t.SkippedContainers += skipped
+		if skipped > 0 && skipped == len(pod.Spec.Containers) {
+			t.SkippedPods++
+		}
+	}
+	if t.SkippedContainers == 0 {
+		return t, false
+	}
+	t.Message = fmt.Sprintf("only the first %d containers are followed; %d containers are skipped, %d pods entirely",
+		maxLogStreams, t.SkippedContainers, t.SkippedPods)
+	return t, true
+}
+
+// HandlePodStream follows one container's logs, like PodHandler.Logs but pushing
+// new lines as they're written. It takes the same ?container and ?tail params,
+// plus ?since=10m to start from a point in time. An "end" message is sent when
+// the container's log stream closes, e.g. because it terminated.
+func (h *LogStreamHandler) HandlePodStream(w http.ResponseWriter, r *http.Request, namespace, name string) {
+	tailLines := int64(500)
+	if tailParam := r.URL.Query().Get("tail"); tailParam != "" {
+		if n, err := strconv.ParseInt(tailParam, 10, 64); err == nil {
+			tailLines = n
+		}
+	}
+
+	opts := corev1.PodLogOptions{TailLines: &tailLines}
+	if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+		d, err := time.ParseDuration(sinceParam)
+		if err != nil || d <= 0 {
+			http.Error(w, "since must be a positive duration", http.StatusBadRequest)
+			return
+		}
+		// The API takes whole seconds; round up so nothing in the window is dropped
+		sinceSeconds := int64((d + time.Second - 1) / time.Second)
+		opts.SinceSeconds = &sinceSeconds
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Fetch the pod before switching to SSE so a typo gets a plain 404; it also
+	// gives the default container
+	pod, err := client.CoreV1().Pods(namespace).Get(r.Context(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		http.Error(w, fmt.Sprintf("pod %s/%s not found", namespace, name), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Multi-container pods need an explicit container; default to the first one
+	container, err := podContainer(pod, r.URL.Query().Get("container"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	opts.Container = container
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	messages := make(chan SSEMessage)
+	go func() {
+		defer close(messages)
+		h.followContainer(ctx, namespace, name, opts, messages)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				sendSSEMessage(w, flusher, SSEMessage{Type: "end", Resource: "logs", Namespace: namespace, Data: name + "/" + opts.Container})
+				return
+			}
+			sendSSEMessage(w, flusher, msg)
+		}
+	}
+}
+
+// followContainer streams one container's logs line by line into messages until
+// ctx is done or the stream ends. opts names the container; Follow is always set.
+func (h *LogStreamHandler) followContainer(ctx context.Context, namespace, pod string, opts corev1.PodLogOptions, messages chan<- SSEMessage) {
+	container := opts.Container
+	opts.Follow = true
+
+	send := func(msg SSEMessage) bool {
+		select {
+		case messages <- msg:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	client, err := h.k8s.GetStreamingClient()
+	if err != nil {
+		send(SSEMessage{Type: "error", Resource: "logs", Namespace: namespace, Data: err.Error()})
+		return
+	}
+
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(pod, &opts).Stream(ctx)
+	if err != nil {
+		send(SSEMessage{Type: "error", Resource: "logs", Namespace: namespace, Data: fmt.Sprintf("%s/%s: %v", pod, container, err)})
+		return
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		msg := SSEMessage{
+			Type:      "log",
+			Resource:  "logs",
+			Namespace: namespace,
+			Data: LogLine{
+				Pod:       pod,
+				Container: container,
+				Line:      fmt.Sprintf("%s/%s %s", pod, container, scanner.Text()),
+			},
+		}
+		if !send(msg) {
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// lookupFailure is a NotFound or Forbidden error from a Get, answered with
+// that status so the UI can tell a missing resource from a denied one and
+// views of it aren't recorded in history. The error body carries found: false
+// or forbidden: true next to the message.
+type lookupFailure struct {
+	message string
+	status  int
+}
+
+func (e lookupFailure) Error() string {
+	return e.message
+}
+
+func (e lookupFailure) StatusCode() int {
+	return e.status
+}
+
+// Response adds the typed fields to GoFr's error body, e.g.
+// {"error":{"message":"...","found":false}}
+func (e lookupFailure) Response() map[string]interface{} {
+	if e.status == http.StatusForbidden {
+		return map[string]interface{}{"forbidden": true}
+	}
+	return map[string]interface{}{"found": false}
+}
+
+// lookupError converts NotFound and Forbidden errors from a Get into a 404 or
+// 403 response. Any other error is returned unchanged.
+func lookupError(err error) (interface{}, error) {
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, lookupFailure{message: err.Error(), status: http.StatusNotFound}
+	case apierrors.IsForbidden(err):
+		return nil, lookupFailure{message: err.Error(), status: http.StatusForbidden}
+	}
+	return nil, err
+}
+
+// badRequestError is invalid input from the caller. GoFr answers errors with a
+// StatusCode method using that status instead of 500.
+type badRequestError struct {
+	message string
+}
+
+func (e badRequestError) Error() string {
+	return e.message
+}
+
+func (e badRequestError) StatusCode() int {
+	return http.StatusBadRequest
+}
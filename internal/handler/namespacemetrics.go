@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+
+	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespaceMetrics combines live usage, declared requests and limits, and
+// quota headroom for one namespace
+type NamespaceMetrics struct {
+	Namespace        string                 `json:"namespace"`
+	Pods             int                    `json:"pods"`             // Pods that aren't Succeeded or Failed
+	MetricsAvailable bool                   `json:"metricsAvailable"` // False when metrics-server isn't reachable; usage is then 0
+	CPU              NamespaceResourceTotal `json:"cpu"`              // Millicores
+	Memory           NamespaceResourceTotal `json:"memory"`           // Bytes
+}
+
+// NamespaceResourceTotal is one resource summed over the namespace's pods
+type NamespaceResourceTotal struct {
+	Usage         int64       `json:"usage"`
+	Requests      int64       `json:"requests"`
+	Limits        int64       `json:"limits"`
+	RequestsQuota *QuotaLimit `json:"requestsQuota,omitempty"`
+	LimitsQuota   *QuotaLimit `json:"limitsQuota,omitempty"`
+}
+
+// QuotaLimit is the ResourceQuota with the least headroom for a resource.
+// Used comes from the quota's own status, so it can differ slightly from the
+// summed requests while the quota controller catches up.
+type QuotaLimit struct {
+	Quota    string `json:"quota"`
+	Hard     int64  `json:"hard"`
+	Used     int64  `json:"used"`
+	Headroom int64  `json:"headroom"`
+}
+
+// Metrics sums pod usage from metrics-server and container requests and limits
+// across the namespace, and compares them against its ResourceQuotas. When
+// several quotas cap the same resource the tightest one is shown; scoped
+// quotas (e.g. BestEffort only) are included as-is.
+func (h *NamespaceHandler) Metrics(ctx *gofr.Context) (interface{}, error) {
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{}); err != nil {
+		return lookupError(err)
+	}
+
+	pods, err := client.CoreV1().Pods(name).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := NamespaceMetrics{Namespace: name}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		result.Pods++
+		for _, c := range pod.Spec.Containers {
+			result.CPU.Requests += c.Resources.Requests.Cpu().MilliValue()
+			result.CPU.Limits += c.Resources.Limits.Cpu().MilliValue()
+			result.Memory.Requests += c.Resources.Requests.Memory().Value()
+			result.Memory.Limits += c.Resources.Limits.Memory().Value()
+		}
+	}
+
+	if mc, err := h.k8s.GetMetricsClient(); err == nil {
+		podMetrics, err := mc.MetricsV1beta1().PodMetricses(name).List(context.Background(), listOptions(metav1.ListOptions{}))
+		if err == nil {
+			result.MetricsAvailable = true
+			for _, pm := range podMetrics.Items {
+				for _, cm := range pm.Containers {
+					result.CPU.Usage += cm.Usage.Cpu().MilliValue()
+					result.Memory.Usage += cm.Usage.Memory().Value()
+				}
+			}
+		}
+	}
+
+	quotas, err := client.CoreV1().ResourceQuotas(name).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range quotas.Items {
+		quota := &quotas.Items[i]
+		// "cpu" and "memory" are shorthand for requests.cpu and requests.memory
+		tightenQuota(&result.CPU.RequestsQuota, quota, corev1.ResourceCPU, true)
+		tightenQuota(&result.CPU.RequestsQuota, quota, corev1.ResourceRequestsCPU, true)
+		tightenQuota(&result.CPU.LimitsQuota, quota, corev1.ResourceLimitsCPU, true)
+		tightenQuota(&result.Memory.RequestsQuota, quota, corev1.ResourceMemory, false)
+		tightenQuota(&result.Memory.RequestsQuota, quota, corev1.ResourceRequestsMemory, false)
+		tightenQuota(&result.Memory.LimitsQuota, quota, corev1.ResourceLimitsMemory, false)
+	}
+
+	return result, nil
+}
+
+// tightenQuota replaces current with the quota's limit for resource if the quota has less headroom
+func tightenQuota(current **QuotaLimit, quota *corev1.ResourceQuota, resource corev1.ResourceName, milli bool) {
+	hard, ok := quota.Status.Hard[resource]
+	if !ok {
+		return
+	}
+	used := quota.Status.Used[resource]
+
+	limit := &QuotaLimit{Quota: quota.Name}
+	if milli {
+		limit.Hard, limit.Used = hard.MilliValue(), used.MilliValue()
+	} else {
+		limit.Hard, limit.Used = hard.Value(), used.Value()
+	}
+	limit.Headroom = limit.Hard - limit.Used
+
+	if *current == nil || limit.Headroom < (*current).Headroom {
+		*current = limit
+	}
+}
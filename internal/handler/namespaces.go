@@ -2,9 +2,16 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"sort"
 
 	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/opengittr/kubeui/internal/service"
 )
@@ -18,9 +25,11 @@ func NewNamespaceHandler(k8s *service.K8sManager) *NamespaceHandler {
 }
 
 type NamespaceInfo struct {
-	Name   string `json:"name"`
-	Status string `json:"status"`
-	Age    string `json:"age"`
+	Name              string   `json:"name"`
+	Status            string   `json:"status"`
+	Age               string   `json:"age"`
+	Finalizers        []string `json:"finalizers,omitempty"`
+	DeletionTimestamp string   `json:"deletionTimestamp,omitempty"`
 }
 
 // List returns all namespaces in the current cluster
@@ -30,19 +39,394 @@ func (h *NamespaceHandler) List(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	namespaces, err := client.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+	namespaces, err := client.CoreV1().Namespaces().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
 
 	var result []NamespaceInfo
 	for _, ns := range namespaces.Items {
+		// Namespace deletion waits on spec.finalizers (e.g. "kubernetes") as well as metadata.finalizers
+		finalizers := ns.Finalizers
+		for _, f := range ns.Spec.Finalizers {
+			finalizers = append(finalizers, string(f))
+		}
+
 		result = append(result, NamespaceInfo{
-			Name:   ns.Name,
-			Status: string(ns.Status.Phase),
-			Age:    formatAge(ns.CreationTimestamp.Time),
+			Name:              ns.Name,
+			Status:            string(ns.Status.Phase),
+			Age:               formatAge(ns.CreationTimestamp.Time),
+			Finalizers:        finalizers,
+			DeletionTimestamp: formatDeletionTimestamp(ns.DeletionTimestamp),
 		})
 	}
 
 	return result, nil
 }
+
+type NamespacePorts struct {
+	Namespace      string                 `json:"namespace"`
+	ContainerPorts []ExposedContainerPort `json:"containerPorts"`
+	ServicePorts   []ExposedServicePort   `json:"servicePorts"`
+}
+
+type ExposedContainerPort struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Workload  string `json:"workload,omitempty"` // "Kind/name" of the top-level owner
+	Name      string `json:"name,omitempty"`
+	Port      int32  `json:"port"`
+	HostPort  int32  `json:"hostPort,omitempty"`
+	Protocol  string `json:"protocol"`
+}
+
+type ExposedServicePort struct {
+	Service    string   `json:"service"`
+	Type       string   `json:"type"`
+	Name       string   `json:"name,omitempty"`
+	Port       int32    `json:"port"`
+	TargetPort string   `json:"targetPort"`
+	NodePort   int32    `json:"nodePort,omitempty"`
+	Protocol   string   `json:"protocol"`
+	Workloads  []string `json:"workloads,omitempty"` // workloads of the pods selected by the service
+}
+
+// Ports returns the network surface of a namespace: every container port and
+// service port, together with the workloads behind them
+func (h *NamespaceHandler) Ports(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := client.CoreV1().Services(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	// Map ReplicaSets to their Deployments so pods resolve to the top-level workload
+	rsOwners := make(map[string]string)
+	if rsList, err := client.AppsV1().ReplicaSets(namespace).List(context.Background(), listOptions(metav1.ListOptions{})); err == nil {
+		for _, rs := range rsList.Items {
+			if owner := metav1.GetControllerOf(&rs); owner != nil {
+				rsOwners[rs.Name] = fmt.Sprintf("%s/%s", owner.Kind, owner.Name)
+			}
+		}
+	}
+
+	result := NamespacePorts{
+		Namespace:      namespace,
+		ContainerPorts: []ExposedContainerPort{},
+		ServicePorts:   []ExposedServicePort{},
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		workload := podWorkload(pod, rsOwners)
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				result.ContainerPorts = append(result.ContainerPorts, ExposedContainerPort{
+					Pod:       pod.Name,
+					Container: c.Name,
+					Workload:  workload,
+					Name:      p.Name,
+					Port:      p.ContainerPort,
+					HostPort:  p.HostPort,
+					Protocol:  string(p.Protocol),
+				})
+			}
+		}
+	}
+
+	for _, svc := range services.Items {
+		// Resolve which workloads the service selects
+		var workloads []string
+		if len(svc.Spec.Selector) > 0 {
+			selector := labels.SelectorFromSet(svc.Spec.Selector)
+			seen := make(map[string]bool)
+			for i := range pods.Items {
+				pod := &pods.Items[i]
+				if !selector.Matches(labels.Set(pod.Labels)) {
+					continue
+				}
+				workload := podWorkload(pod, rsOwners)
+				if workload == "" {
+					workload = "Pod/" + pod.Name
+				}
+				if !seen[workload] {
+					seen[workload] = true
+					workloads = append(workloads, workload)
+				}
+			}
+			sort.Strings(workloads)
+		}
+
+		for _, p := range svc.Spec.Ports {
+			result.ServicePorts = append(result.ServicePorts, ExposedServicePort{
+				Service:    svc.Name,
+				Type:       string(svc.Spec.Type),
+				Name:       p.Name,
+				Port:       p.Port,
+				TargetPort: p.TargetPort.String(),
+				NodePort:   p.NodePort,
+				Protocol:   string(p.Protocol),
+				Workloads:  workloads,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// podWorkload returns "Kind/name" of the pod's top-level controller, following
+// ReplicaSets up to their Deployment. Returns "" for unmanaged pods.
+func podWorkload(pod *corev1.Pod, rsOwners map[string]string) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return ""
+	}
+	if owner.Kind == "ReplicaSet" {
+		if deployment, ok := rsOwners[owner.Name]; ok {
+			return deployment
+		}
+	}
+	return fmt.Sprintf("%s/%s", owner.Kind, owner.Name)
+}
+
+// Annotations recording state before a namespace scale-down, so it can be restored
+const (
+	previousReplicasAnnotation = "kubeui.io/previous-replicas"
+	previousSuspendAnnotation  = "kubeui.io/previous-suspend"
+)
+
+// ScaleDownResult reports what happened to one workload during a namespace scale-down
+type ScaleDownResult struct {
+	Kind             string `json:"kind"`
+	Name             string `json:"name"`
+	PreviousReplicas *int32 `json:"previousReplicas,omitempty"`
+	Action           string `json:"action"`        // "scaled to 0", "suspended", "skipped", "managed by HPA", or "failed"
+	HPA              string `json:"hpa,omitempty"` // The HorizontalPodAutoscaler that would scale the workload back up
+	Error            string `json:"error,omitempty"`
+}
+
+// ScaleDown scales every deployment and statefulset in the namespace to zero and
+// suspends every cronjob, recording prior state in annotations. Workloads an HPA
+// targets are left alone, since the HPA would scale them straight back up.
+func (h *NamespaceHandler) ScaleDown(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	cronJobs, err := client.BatchV1().CronJobs(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	hpas := scaleTargetHPAs(client, namespace)
+	result := []ScaleDownResult{}
+
+	for _, d := range deployments.Items {
+		res := scaleDownResult("Deployment", d.Name, d.Spec.Replicas, hpas)
+		if res.Action == "" {
+			_, err := client.AppsV1().Deployments(namespace).Patch(context.Background(), d.Name, types.MergePatchType, scaleDownPatch(*res.PreviousReplicas), metav1.PatchOptions{})
+			res.finish(err, "scaled to 0")
+		}
+		result = append(result, res)
+	}
+
+	for _, ss := range statefulSets.Items {
+		res := scaleDownResult("StatefulSet", ss.Name, ss.Spec.Replicas, hpas)
+		if res.Action == "" {
+			_, err := client.AppsV1().StatefulSets(namespace).Patch(context.Background(), ss.Name, types.MergePatchType, scaleDownPatch(*res.PreviousReplicas), metav1.PatchOptions{})
+			res.finish(err, "scaled to 0")
+		}
+		result = append(result, res)
+	}
+
+	for _, cj := range cronJobs.Items {
+		res := ScaleDownResult{Kind: "CronJob", Name: cj.Name}
+		if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+			res.Action = "skipped"
+		} else {
+			patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:"false"}},"spec":{"suspend":true}}`, previousSuspendAnnotation)
+			_, err := client.BatchV1().CronJobs(namespace).Patch(context.Background(), cj.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+			res.finish(err, "suspended")
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+// scaleTargetHPAs maps "Kind/name" of each HPA's scale target to the HPA's name.
+// Like findDeploymentHPA it's best effort: a failed list finds no HPAs.
+func scaleTargetHPAs(client kubernetes.Interface, namespace string) map[string]string {
+	targets := make(map[string]string)
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return targets
+	}
+	for _, hpa := range hpas.Items {
+		targets[hpa.Spec.ScaleTargetRef.Kind+"/"+hpa.Spec.ScaleTargetRef.Name] = hpa.Name
+	}
+	return targets
+}
+
+// scaleDownResult starts a result for a scalable workload, marking it skipped if
+// already at zero or managed by one of hpas
+func scaleDownResult(kind, name string, replicas *int32, hpas map[string]string) ScaleDownResult {
+	// A nil replica count defaults to 1
+	previous := int32(1)
+	if replicas != nil {
+		previous = *replicas
+	}
+
+	res := ScaleDownResult{Kind: kind, Name: name, PreviousReplicas: &previous}
+	switch hpa, managed := hpas[kind+"/"+name]; {
+	case previous == 0:
+		res.Action = "skipped"
+	case managed:
+		res.Action = "managed by HPA"
+		res.HPA = hpa
+	}
+	return res
+}
+
+func (r *ScaleDownResult) finish(err error, action string) {
+	if err != nil {
+		r.Action = "failed"
+		r.Error = err.Error()
+		return
+	}
+	r.Action = action
+}
+
+// scaleDownPatch sets replicas to zero and records the previous count
+func scaleDownPatch(previous int32) []byte {
+	return []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:"%d"}},"spec":{"replicas":0}}`, previousReplicasAnnotation, previous))
+}
+
+// Scope returns how a request with the given namespace param would be scoped
+func (h *NamespaceHandler) Scope(ctx *gofr.Context) (interface{}, error) {
+	return resolveNamespace(ctx, h.k8s), nil
+}
+
+type selectNamespaceRequest struct {
+	Namespace string `json:"namespace"`
+}
+
+// SelectNamespace sets the session namespace used when requests don't name one.
+// An empty namespace clears the selection.
+func (h *NamespaceHandler) SelectNamespace(ctx *gofr.Context) (interface{}, error) {
+	var req selectNamespaceRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+
+	h.k8s.SetSelectedNamespace(req.Namespace)
+	return resolveNamespace(ctx, h.k8s), nil
+}
+
+// podSecurityLabelPrefix is the namespace label prefix Pod Security Admission reads,
+// e.g. pod-security.kubernetes.io/enforce=baseline and .../enforce-version=v1.30
+const podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// podSecurityLevels are the valid Pod Security Standards levels
+var podSecurityLevels = map[string]bool{
+	"privileged": true,
+	"baseline":   true,
+	"restricted": true,
+}
+
+// podSecurityVersionPattern matches a pinned policy version such as v1.30
+var podSecurityVersionPattern = regexp.MustCompile(`^v1\.[0-9]+$`)
+
+// PodSecurity is the effective Pod Security Admission configuration of a namespace
+type PodSecurity struct {
+	Namespace string          `json:"namespace"`
+	Enforce   PodSecurityMode `json:"enforce"`
+	Audit     PodSecurityMode `json:"audit"`
+	Warn      PodSecurityMode `json:"warn"`
+	Errors    []string        `json:"errors,omitempty"`
+}
+
+// PodSecurityMode is one mode's level and version. Labeled is false when the
+// namespace has no label for the mode and the level shown is the default.
+type PodSecurityMode struct {
+	Level   string `json:"level"`
+	Version string `json:"version"`
+	Labeled bool   `json:"labeled"`
+}
+
+// PodSecurity reads the namespace's pod-security.kubernetes.io labels. Unlabeled
+// modes default to privileged at version latest; cluster-wide defaults from the
+// admission configuration file aren't visible through the API.
+func (h *NamespaceHandler) PodSecurity(ctx *gofr.Context) (interface{}, error) {
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ns, err := client.CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return lookupError(err)
+	}
+
+	result := PodSecurity{Namespace: ns.Name}
+	result.Enforce = podSecurityMode(ns.Labels, "enforce", &result.Errors)
+	result.Audit = podSecurityMode(ns.Labels, "audit", &result.Errors)
+	result.Warn = podSecurityMode(ns.Labels, "warn", &result.Errors)
+
+	// The admission controller fails closed when the namespace's labels are invalid
+	if len(result.Errors) > 0 {
+		result.Enforce.Level = "restricted"
+		result.Enforce.Version = "latest"
+	}
+
+	return result, nil
+}
+
+func podSecurityMode(nsLabels map[string]string, mode string, errs *[]string) PodSecurityMode {
+	result := PodSecurityMode{Level: "privileged", Version: "latest"}
+
+	levelLabel := podSecurityLabelPrefix + mode
+	if level, ok := nsLabels[levelLabel]; ok {
+		result.Labeled = true
+		if podSecurityLevels[level] {
+			result.Level = level
+		} else {
+			*errs = append(*errs, fmt.Sprintf("%s: invalid level %q", levelLabel, level))
+		}
+	}
+
+	versionLabel := levelLabel + "-version"
+	if version, ok := nsLabels[versionLabel]; ok {
+		if version == "latest" || podSecurityVersionPattern.MatchString(version) {
+			result.Version = version
+		} else {
+			*errs = append(*errs, fmt.Sprintf("%s: invalid version %q", versionLabel, version))
+		}
+	}
+
+	return result
+}
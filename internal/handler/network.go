@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"gofr.dev/pkg/gofr"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/opengittr/kubeui/internal/service"
 )
@@ -31,14 +33,14 @@ type IngressInfo struct {
 }
 
 func (h *NetworkHandler) ListIngresses(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	ingresses, err := client.NetworkingV1().Ingresses(namespace).List(context.Background(), metav1.ListOptions{})
+	ingresses, err := client.NetworkingV1().Ingresses(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -108,14 +110,14 @@ type EndpointInfo struct {
 }
 
 func (h *NetworkHandler) ListEndpoints(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	endpoints, err := client.CoreV1().Endpoints(namespace).List(context.Background(), metav1.ListOptions{})
+	endpoints, err := client.CoreV1().Endpoints(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -160,14 +162,14 @@ type NetworkPolicyInfo struct {
 }
 
 func (h *NetworkHandler) ListNetworkPolicies(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	policies, err := client.NetworkingV1().NetworkPolicies(namespace).List(context.Background(), metav1.ListOptions{})
+	policies, err := client.NetworkingV1().NetworkPolicies(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -239,3 +241,158 @@ func (h *NetworkHandler) DeleteNetworkPolicy(ctx *gofr.Context) (interface{}, er
 
 	return map[string]string{"message": fmt.Sprintf("NetworkPolicy %s deleted", name)}, nil
 }
+
+// EffectiveNetworkPolicy is the combined set of NetworkPolicy rules that apply to a pod
+type EffectiveNetworkPolicy struct {
+	Pod       string   `json:"pod"`
+	Namespace string   `json:"namespace"`
+	Policies  []string `json:"policies"`
+	// A direction is isolated when at least one policy selects the pod for it;
+	// only traffic matching a rule below is then allowed
+	IngressIsolated bool                `json:"ingressIsolated"`
+	EgressIsolated  bool                `json:"egressIsolated"`
+	Ingress         []NetworkPolicyRule `json:"ingress"`
+	Egress          []NetworkPolicyRule `json:"egress"`
+}
+
+// NetworkPolicyRule is one allow rule, tagged with the policy it came from
+type NetworkPolicyRule struct {
+	Policy string   `json:"policy"`
+	Peers  []string `json:"peers"` // "<all>" when the rule matches any peer
+	Ports  []string `json:"ports"` // "<all>" when the rule matches any port
+}
+
+// PodNetworkPolicies returns the NetworkPolicies whose podSelector matches the pod and their combined rules
+func (h *NetworkHandler) PodNetworkPolicies(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := client.NetworkingV1().NetworkPolicies(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := EffectiveNetworkPolicy{
+		Pod:       pod.Name,
+		Namespace: pod.Namespace,
+		Policies:  []string{},
+		Ingress:   []NetworkPolicyRule{},
+		Egress:    []NetworkPolicyRule{},
+	}
+
+	for _, np := range policies.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&np.Spec.PodSelector)
+		if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		result.Policies = append(result.Policies, np.Name)
+
+		// Without explicit policyTypes, Ingress always applies and Egress applies if egress rules exist
+		ingress, egress := false, false
+		for _, pt := range np.Spec.PolicyTypes {
+			switch pt {
+			case networkingv1.PolicyTypeIngress:
+				ingress = true
+			case networkingv1.PolicyTypeEgress:
+				egress = true
+			}
+		}
+		if len(np.Spec.PolicyTypes) == 0 {
+			ingress = true
+			egress = len(np.Spec.Egress) > 0
+		}
+
+		if ingress {
+			result.IngressIsolated = true
+			for _, rule := range np.Spec.Ingress {
+				result.Ingress = append(result.Ingress, NetworkPolicyRule{
+					Policy: np.Name,
+					Peers:  formatPolicyPeers(rule.From),
+					Ports:  formatPolicyPorts(rule.Ports),
+				})
+			}
+		}
+		if egress {
+			result.EgressIsolated = true
+			for _, rule := range np.Spec.Egress {
+				result.Egress = append(result.Egress, NetworkPolicyRule{
+					Policy: np.Name,
+					Peers:  formatPolicyPeers(rule.To),
+					Ports:  formatPolicyPorts(rule.Ports),
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// formatPolicyPeers describes each peer as "pods: ...", "namespaces: ...", or "ipBlock: ..."
+func formatPolicyPeers(peers []networkingv1.NetworkPolicyPeer) []string {
+	if len(peers) == 0 {
+		return []string{"<all>"}
+	}
+
+	var result []string
+	for _, peer := range peers {
+		switch {
+		case peer.IPBlock != nil:
+			block := "ipBlock: " + peer.IPBlock.CIDR
+			if len(peer.IPBlock.Except) > 0 {
+				block += " except " + strings.Join(peer.IPBlock.Except, ", ")
+			}
+			result = append(result, block)
+		case peer.PodSelector != nil && peer.NamespaceSelector != nil:
+			result = append(result, fmt.Sprintf("pods: %s in namespaces: %s", formatPeerSelector(peer.PodSelector), formatPeerSelector(peer.NamespaceSelector)))
+		case peer.PodSelector != nil:
+			result = append(result, "pods: "+formatPeerSelector(peer.PodSelector))
+		case peer.NamespaceSelector != nil:
+			result = append(result, "namespaces: "+formatPeerSelector(peer.NamespaceSelector))
+		}
+	}
+	return result
+}
+
+// formatPeerSelector formats a label selector, using "<all>" for the empty selector
+func formatPeerSelector(selector *metav1.LabelSelector) string {
+	formatted := metav1.FormatLabelSelector(selector)
+	if formatted == "" || formatted == "<none>" {
+		return "<all>"
+	}
+	return formatted
+}
+
+// formatPolicyPorts describes each port as "PROTOCOL/port" or "PROTOCOL/port-endPort"
+func formatPolicyPorts(ports []networkingv1.NetworkPolicyPort) []string {
+	if len(ports) == 0 {
+		return []string{"<all>"}
+	}
+
+	var result []string
+	for _, p := range ports {
+		protocol := "TCP"
+		if p.Protocol != nil {
+			protocol = string(*p.Protocol)
+		}
+
+		port := "<all>"
+		if p.Port != nil {
+			port = p.Port.String()
+			if p.EndPort != nil {
+				port = fmt.Sprintf("%s-%d", port, *p.EndPort)
+			}
+		}
+		result = append(result, protocol+"/"+port)
+	}
+	return result
+}
@@ -31,6 +31,7 @@ type NodeInfo struct {
 	CPU              NodeResource      `json:"cpu"`
 	Memory           NodeResource      `json:"memory"`
 	Pods             NodeResource      `json:"pods"`
+	Storage          NodeStorage       `json:"storage"`
 	Labels           map[string]string `json:"labels"`
 	Conditions       []NodeCondition   `json:"conditions"`
 }
@@ -40,6 +41,16 @@ type NodeResource struct {
 	Requested int64 `json:"requested"` // Currently requested/used
 }
 
+// NodeStorage is the node's ephemeral storage headroom. The kubelet reports
+// both low disk space and low inodes through the DiskPressure condition.
+type NodeStorage struct {
+	Capacity        int64  `json:"capacity"`    // ephemeral-storage in bytes
+	Allocatable     int64  `json:"allocatable"` // ephemeral-storage available to pods in bytes
+	Requested       int64  `json:"requested"`   // ephemeral-storage requested by pods in bytes
+	DiskPressure    bool   `json:"diskPressure"`
+	PressureMessage string `json:"pressureMessage,omitempty"`
+}
+
 type NodeCondition struct {
 	Type    string `json:"type"`
 	Status  string `json:"status"`
@@ -52,21 +63,22 @@ func (h *NodeHandler) List(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	nodes, err := client.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	nodes, err := client.CoreV1().Nodes().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
 
 	// Get all pods to count per node
-	pods, err := client.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+	pods, err := client.CoreV1().Pods("").List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
 
 	// Count pods and resource requests per node
 	podCountByNode := make(map[string]int)
-	cpuRequestsByNode := make(map[string]int64)    // millicores
-	memoryRequestsByNode := make(map[string]int64) // bytes
+	cpuRequestsByNode := make(map[string]int64)     // millicores
+	memoryRequestsByNode := make(map[string]int64)  // bytes
+	storageRequestsByNode := make(map[string]int64) // bytes
 
 	for _, pod := range pods.Items {
 		if pod.Spec.NodeName != "" && pod.Status.Phase != "Succeeded" && pod.Status.Phase != "Failed" {
@@ -80,6 +92,9 @@ func (h *NodeHandler) List(ctx *gofr.Context) (interface{}, error) {
 				if mem := container.Resources.Requests.Memory(); mem != nil {
 					memoryRequestsByNode[pod.Spec.NodeName] += mem.Value()
 				}
+				if storage := container.Resources.Requests.StorageEphemeral(); storage != nil {
+					storageRequestsByNode[pod.Spec.NodeName] += storage.Value()
+				}
 			}
 		}
 	}
@@ -88,6 +103,11 @@ func (h *NodeHandler) List(ctx *gofr.Context) (interface{}, error) {
 	for _, node := range nodes.Items {
 		// Determine status
 		status := "Unknown"
+		storage := NodeStorage{
+			Capacity:    node.Status.Capacity.StorageEphemeral().Value(),
+			Allocatable: node.Status.Allocatable.StorageEphemeral().Value(),
+			Requested:   storageRequestsByNode[node.Name],
+		}
 		var conditions []NodeCondition
 		for _, cond := range node.Status.Conditions {
 			conditions = append(conditions, NodeCondition{
@@ -95,6 +115,10 @@ func (h *NodeHandler) List(ctx *gofr.Context) (interface{}, error) {
 				Status:  string(cond.Status),
 				Message: cond.Message,
 			})
+			if cond.Type == "DiskPressure" && cond.Status == "True" {
+				storage.DiskPressure = true
+				storage.PressureMessage = cond.Message
+			}
 			if cond.Type == "Ready" {
 				if cond.Status == "True" {
 					status = "Ready"
@@ -158,6 +182,7 @@ func (h *NodeHandler) List(ctx *gofr.Context) (interface{}, error) {
 			CPU:              NodeResource{Capacity: cpuCapacity, Requested: cpuRequested},
 			Memory:           NodeResource{Capacity: memoryCapacity, Requested: memoryRequested},
 			Pods:             NodeResource{Capacity: podsCapacity, Requested: currentPods},
+			Storage:          storage,
 			Labels:           node.Labels,
 			Conditions:       conditions,
 		})
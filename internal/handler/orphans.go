@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// systemNamespaces hold cluster components whose unowned objects are expected
+var systemNamespaces = map[string]bool{
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+}
+
+// systemConfigMaps are published into every namespace by the control plane
+var systemConfigMaps = map[string]bool{
+	"kube-root-ca.crt":   true,
+	"istio-ca-root-cert": true,
+}
+
+// OrphanHandler finds unowned resources that are likely leftovers from manual work
+type OrphanHandler struct {
+	k8s    *service.K8sManager
+	filter *ResourceFilter
+}
+
+func NewOrphanHandler(k8s *service.K8sManager, filter *ResourceFilter) *OrphanHandler {
+	return &OrphanHandler{k8s: k8s, filter: filter}
+}
+
+type OrphanResource struct {
+	Type      string `json:"type"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	CreatedAt string `json:"createdAt"`
+	Age       string `json:"age"`
+
+	created time.Time
+}
+
+// OrphanReport lists the orphans found and the types whose ConfigMap usage
+// couldn't be checked, whose ConfigMaps may be reported although in use
+type OrphanReport struct {
+	Orphans   []OrphanResource `json:"orphans"`
+	Unchecked []string         `json:"unchecked,omitempty"` // e.g. "cronjobs: forbidden"
+}
+
+// List returns ReplicaSets, Pods, Jobs and ConfigMaps without ownerReferences,
+// oldest first. Objects in system namespaces, objects labeled with a managing
+// tool (e.g. Helm) and ConfigMaps that a pod or workload template uses are
+// left out. Workload types that are disabled or forbidden can't be checked for
+// ConfigMap usage and are listed as unchecked.
+func (h *OrphanHandler) List(ctx *gofr.Context) (interface{}, error) {
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []OrphanResource
+	add := func(resourceType string, meta metav1.ObjectMeta) {
+		if len(meta.OwnerReferences) > 0 || systemNamespaces[meta.Namespace] || meta.Labels["app.kubernetes.io/managed-by"] != "" {
+			return
+		}
+		orphans = append(orphans, OrphanResource{
+			Type:      resourceType,
+			Kind:      resourceMetaMap[resourceType].kind,
+			Name:      meta.Name,
+			Namespace: meta.Namespace,
+			CreatedAt: meta.CreationTimestamp.Format(time.RFC3339),
+			Age:       formatAge(meta.CreationTimestamp.Time),
+			created:   meta.CreationTimestamp.Time,
+		})
+	}
+
+	if h.filter.Enabled("replicasets") {
+		replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err != nil {
+			return nil, err
+		}
+		for _, rs := range replicaSets.Items {
+			add("replicasets", rs.ObjectMeta)
+		}
+	}
+
+	// Pods are also scanned for ConfigMap usage
+	var pods []corev1.Pod
+	if h.filter.Enabled("pods") {
+		podList, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err != nil {
+			return nil, err
+		}
+		pods = podList.Items
+		for _, pod := range pods {
+			add("pods", pod.ObjectMeta)
+		}
+	}
+
+	if h.filter.Enabled("jobs") {
+		jobs, err := client.BatchV1().Jobs(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs.Items {
+			add("jobs", job.ObjectMeta)
+		}
+	}
+
+	var unchecked []string
+	if h.filter.Enabled("configmaps") {
+		configMaps, err := client.CoreV1().ConfigMaps(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err != nil {
+			return nil, err
+		}
+
+		used, skipped, err := usedConfigMaps(ctx, client, h.filter, namespace, pods)
+		if err != nil {
+			return nil, err
+		}
+		if !h.filter.Enabled("pods") {
+			skipped = append([]string{"pods: disabled"}, skipped...)
+		}
+		unchecked = skipped
+		for _, cm := range configMaps.Items {
+			// Leader election and controller state is kept in ConfigMaps named *-lock or *-leader
+			if systemConfigMaps[cm.Name] || used[cm.Namespace+"/"+cm.Name] || strings.HasSuffix(cm.Name, "-lock") || strings.HasSuffix(cm.Name, "-leader") {
+				continue
+			}
+			add("configmaps", cm.ObjectMeta)
+		}
+	}
+
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return orphans[i].created.Before(orphans[j].created)
+	})
+	if orphans == nil {
+		orphans = []OrphanResource{}
+	}
+	return OrphanReport{Orphans: orphans, Unchecked: unchecked}, nil
+}
+
+// usedConfigMaps returns the namespace/name of every ConfigMap referenced by a
+// pod or a workload's pod template, so ones only used by a CronJob between
+// runs or a workload scaled to zero aren't reported as orphans. Workload types
+// that are disabled or can't be listed are skipped and returned as unchecked.
+func usedConfigMaps(ctx *gofr.Context, client kubernetes.Interface, filter *ResourceFilter, namespace string, pods []corev1.Pod) (map[string]bool, []string, error) {
+	used := make(map[string]bool)
+	add := func(ns string, spec *corev1.PodSpec) {
+		for _, ref := range templateConfigReferences(spec) {
+			if ref.Kind == "ConfigMap" {
+				used[ns+"/"+ref.Name] = true
+			}
+		}
+	}
+
+	for i := range pods {
+		add(pods[i].Namespace, &pods[i].Spec)
+	}
+
+	// scan runs one workload type's list, recording the type as unchecked when
+	// it's disabled or forbidden
+	var unchecked []string
+	scan := func(resourceType string, list func() error) error {
+		if !filter.Enabled(resourceType) {
+			unchecked = append(unchecked, resourceType+": disabled")
+			return nil
+		}
+		err := list()
+		if apierrors.IsForbidden(err) {
+			unchecked = append(unchecked, resourceType+": forbidden")
+			return nil
+		}
+		return err
+	}
+	opts := listOptionsFor(ctx, metav1.ListOptions{})
+
+	err := scan("deployments", func() error {
+		deployments, err := client.AppsV1().Deployments(namespace).List(context.Background(), opts)
+		if err != nil {
+			return err
+		}
+		for i := range deployments.Items {
+			add(deployments.Items[i].Namespace, &deployments.Items[i].Spec.Template.Spec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = scan("statefulsets", func() error {
+		statefulSets, err := client.AppsV1().StatefulSets(namespace).List(context.Background(), opts)
+		if err != nil {
+			return err
+		}
+		for i := range statefulSets.Items {
+			add(statefulSets.Items[i].Namespace, &statefulSets.Items[i].Spec.Template.Spec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = scan("daemonsets", func() error {
+		daemonSets, err := client.AppsV1().DaemonSets(namespace).List(context.Background(), opts)
+		if err != nil {
+			return err
+		}
+		for i := range daemonSets.Items {
+			add(daemonSets.Items[i].Namespace, &daemonSets.Items[i].Spec.Template.Spec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = scan("replicasets", func() error {
+		replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(context.Background(), opts)
+		if err != nil {
+			return err
+		}
+		for i := range replicaSets.Items {
+			add(replicaSets.Items[i].Namespace, &replicaSets.Items[i].Spec.Template.Spec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = scan("jobs", func() error {
+		jobs, err := client.BatchV1().Jobs(namespace).List(context.Background(), opts)
+		if err != nil {
+			return err
+		}
+		for i := range jobs.Items {
+			add(jobs.Items[i].Namespace, &jobs.Items[i].Spec.Template.Spec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = scan("cronjobs", func() error {
+		cronJobs, err := client.BatchV1().CronJobs(namespace).List(context.Background(), opts)
+		if err != nil {
+			return err
+		}
+		for i := range cronJobs.Items {
+			add(cronJobs.Items[i].Namespace, &cronJobs.Items[i].Spec.JobTemplate.Spec.Template.Spec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return used, unchecked, nil
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"gofr.dev/pkg/gofr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
@@ -17,11 +21,14 @@ import (
 )
 
 type PodHandler struct {
-	k8s *service.K8sManager
+	k8s    *service.K8sManager
+	filter *ResourceFilter
 }
 
-func NewPodHandler(k8s *service.K8sManager) *PodHandler {
-	return &PodHandler{k8s: k8s}
+// NewPodHandler creates a pod handler. Secret values referenced by env are
+// only resolved when the filter allows secrets.
+func NewPodHandler(k8s *service.K8sManager, filter *ResourceFilter) *PodHandler {
+	return &PodHandler{k8s: k8s, filter: filter}
 }
 
 type PodInfo struct {
@@ -36,6 +43,59 @@ type PodInfo struct {
 	Ports      []ContainerPort   `json:"ports,omitempty"`
 	Containers []ContainerInfo   `json:"containers,omitempty"`
 	Labels     map[string]string `json:"labels,omitempty"`
+	// Scheduling priority resolved by the API server from the PriorityClass
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	Priority          *int32 `json:"priority,omitempty"`
+	// RuntimeClass selecting the container runtime, e.g. gVisor or Kata
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+	// Set while the pod is terminating
+	Finalizers        []string `json:"finalizers,omitempty"`
+	DeletionTimestamp string   `json:"deletionTimestamp,omitempty"`
+	// How long the kubelet waits after preStop and SIGTERM before killing containers
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Pod conditions and custom readiness gates, which can hold a pod NotReady with all containers ready
+	Conditions     []PodCondition        `json:"conditions,omitempty"`
+	ReadinessGates []ReadinessGateStatus `json:"readinessGates,omitempty"`
+	NotReadyReason string                `json:"notReadyReason,omitempty"` // Where the pod is stuck, e.g. "PodScheduled=False: 0/5 nodes are available"
+	// Init and ephemeral (kubectl debug) containers, so logs and exec can target them
+	InitContainers      []ContainerInfo `json:"initContainers,omitempty"`
+	EphemeralContainers []ContainerInfo `json:"ephemeralContainers,omitempty"`
+	// Health of the node the pod runs on; only filled in by Get
+	NodeHealth *PodNodeHealth `json:"nodeHealth,omitempty"`
+}
+
+// PodNodeHealth is the Ready and pressure conditions of a pod's node, so a
+// NotReady or evicted pod can be traced to the node without opening it
+type PodNodeHealth struct {
+	Name          string          `json:"name"`
+	Ready         bool            `json:"ready"`
+	Unschedulable bool            `json:"unschedulable,omitempty"` // Cordoned
+	Conditions    []NodeCondition `json:"conditions,omitempty"`
+	Problems      []string        `json:"problems,omitempty"` // e.g. "MemoryPressure=True: kubelet has insufficient memory available"
+	Error         string          `json:"error,omitempty"`    // Set when the node couldn't be read, e.g. no permission to get nodes
+}
+
+// podNodeConditionTypes are the node conditions that affect the pods on it
+var podNodeConditionTypes = []corev1.NodeConditionType{
+	corev1.NodeReady,
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+	corev1.NodeNetworkUnavailable,
+}
+
+type PodCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ReadinessGateStatus is a spec.readinessGates entry and its matching condition.
+// Status is "Missing" until the controller responsible for the gate sets it.
+type ReadinessGateStatus struct {
+	ConditionType string `json:"conditionType"`
+	Status        string `json:"status"`
 }
 
 type ContainerPort struct {
@@ -47,12 +107,30 @@ type ContainerPort struct {
 type ContainerInfo struct {
 	Name         string            `json:"name"`
 	Image        string            `json:"image"`
+	ImageID      string            `json:"imageID,omitempty"` // Resolved digest of the running image
 	Ready        bool              `json:"ready"`
 	RestartCount int32             `json:"restartCount"`
 	State        string            `json:"state"`
 	Ports        []ContainerPort   `json:"ports,omitempty"`
 	Resources    ContainerResource `json:"resources,omitempty"`
 	Env          []EnvVar          `json:"env,omitempty"`
+	Lifecycle    *LifecycleHooks   `json:"lifecycle,omitempty"`
+	StartupProbe string            `json:"startupProbe,omitempty"` // Probe description, if one is configured
+	Started      *bool             `json:"started,omitempty"`      // Whether the startup probe has passed
+
+	// Names declared more than once across env and envFrom
+	EnvConflicts []EnvConflict `json:"envConflicts,omitempty"`
+}
+
+// LifecycleHooks are a container's postStart and preStop handlers
+type LifecycleHooks struct {
+	PostStart *LifecycleHandler `json:"postStart,omitempty"`
+	PreStop   *LifecycleHandler `json:"preStop,omitempty"`
+}
+
+type LifecycleHandler struct {
+	Type   string `json:"type"`   // exec, httpGet, tcpSocket or sleep
+	Action string `json:"action"` // The command, URL, address or duration
 }
 
 type EnvVar struct {
@@ -61,6 +139,52 @@ type EnvVar struct {
 	ValueFrom string `json:"valueFrom,omitempty"` // "configmap:name/key", "secret:name/key", "field:path"
 }
 
+// EnvConflict is an env name a container declares more than once. The last
+// declaration wins: env over envFrom, and later entries over earlier ones.
+type EnvConflict struct {
+	Name       string   `json:"name"`
+	Winner     string   `json:"winner"`     // Source whose value takes effect
+	Overridden []string `json:"overridden"` // Earlier sources, in declaration order
+}
+
+// envConflicts finds duplicate names in a container's env list, where the
+// first fromCount entries were expanded from envFrom. Unexpanded envFrom
+// sources can't be checked.
+func envConflicts(env []EnvVar, fromCount int) []EnvConflict {
+	sources := make(map[string][]string)
+	var order []string
+	for i, ev := range env {
+		if strings.HasSuffix(ev.Name, "* (all keys)") {
+			continue
+		}
+		source := "env value"
+		switch {
+		case i < fromCount:
+			source = "envFrom " + ev.ValueFrom
+		case ev.ValueFrom != "":
+			source = "env " + ev.ValueFrom
+		}
+		if _, ok := sources[ev.Name]; !ok {
+			order = append(order, ev.Name)
+		}
+		sources[ev.Name] = append(sources[ev.Name], source)
+	}
+
+	var conflicts []EnvConflict
+	for _, name := range order {
+		declared := sources[name]
+		if len(declared) < 2 {
+			continue
+		}
+		conflicts = append(conflicts, EnvConflict{
+			Name:       name,
+			Winner:     declared[len(declared)-1],
+			Overridden: declared[:len(declared)-1],
+		})
+	}
+	return conflicts
+}
+
 type ContainerResource struct {
 	CPU    ResourceUsage `json:"cpu"`
 	Memory ResourceUsage `json:"memory"`
@@ -74,7 +198,7 @@ type ResourceUsage struct {
 
 // List returns all pods, optionally filtered by namespace
 func (h *PodHandler) List(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 	if namespace == "" {
 		namespace = "" // empty means all namespaces
 	}
@@ -84,19 +208,56 @@ func (h *PodHandler) List(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
 
+	// Optionally keep only pods with a matching ownerReference, e.g. ownedBy=ReplicaSet/nginx-abc123
+	if ownedBy := ctx.Param("ownedBy"); ownedBy != "" {
+		kind, owner, found := strings.Cut(ownedBy, "/")
+		if !found || kind == "" || owner == "" {
+			return nil, badRequestError{fmt.Sprintf("invalid ownedBy %q, expected Kind/name", ownedBy)}
+		}
+		pods.Items = filterPodsByOwner(pods.Items, kind, owner)
+	}
+
 	var result []PodInfo
 	for _, pod := range pods.Items {
 		result = append(result, podToInfo(&pod, false))
 	}
 
+	// Optionally bucket pods by the value of a label key
+	if groupBy := ctx.Param("groupBy"); groupBy != "" {
+		groups := make(map[string][]PodInfo)
+		for i, pod := range pods.Items {
+			value, ok := pod.Labels[groupBy]
+			if !ok {
+				value = "<none>"
+			}
+			groups[value] = append(groups[value], result[i])
+		}
+		return map[string]interface{}{"groups": groups}, nil
+	}
+
 	return result, nil
 }
 
+// filterPodsByOwner returns the pods with an ownerReference of the given kind
+// (case-insensitive) and name
+func filterPodsByOwner(pods []corev1.Pod, kind, name string) []corev1.Pod {
+	var filtered []corev1.Pod
+	for _, pod := range pods {
+		for _, ref := range pod.OwnerReferences {
+			if strings.EqualFold(ref.Kind, kind) && ref.Name == name {
+				filtered = append(filtered, pod)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 // Get returns details of a specific pod
 func (h *PodHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	namespace := ctx.PathParam("namespace")
@@ -109,7 +270,7 @@ func (h *PodHandler) Get(ctx *gofr.Context) (interface{}, error) {
 
 	pod, err := client.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	// Try to get metrics (may fail if metrics-server not available)
@@ -119,7 +280,52 @@ func (h *PodHandler) Get(ctx *gofr.Context) (interface{}, error) {
 		containerMetrics = fetchPodMetrics(metricsClient, namespace, name)
 	}
 
-	return podToInfoWithMetrics(pod, containerMetrics, client, namespace), nil
+	info := podToInfoWithMetrics(pod, containerMetrics, client, namespace, h.filter)
+	if pod.Spec.NodeName != "" {
+		info.NodeHealth = podNodeHealth(client, pod.Spec.NodeName)
+	}
+	return info, nil
+}
+
+// podNodeHealth reads the node's Ready and pressure conditions. A failed
+// lookup is reported in Error rather than failing the pod request, since
+// namespace-scoped users often can't get nodes.
+func podNodeHealth(client kubernetes.Interface, nodeName string) *PodNodeHealth {
+	health := &PodNodeHealth{Name: nodeName}
+
+	node, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+
+	health.Ready = nodeIsReady(node)
+	health.Unschedulable = node.Spec.Unschedulable
+	for _, condType := range podNodeConditionTypes {
+		for _, cond := range node.Status.Conditions {
+			if cond.Type != condType {
+				continue
+			}
+			health.Conditions = append(health.Conditions, NodeCondition{
+				Type:    string(cond.Type),
+				Status:  string(cond.Status),
+				Message: cond.Message,
+			})
+			// Ready is the only condition where True is healthy
+			if (cond.Type == corev1.NodeReady) != (cond.Status == corev1.ConditionTrue) {
+				problem := fmt.Sprintf("%s=%s", cond.Type, cond.Status)
+				if cond.Message != "" {
+					problem += ": " + cond.Message
+				}
+				health.Problems = append(health.Problems, problem)
+			}
+		}
+	}
+	if health.Unschedulable {
+		health.Problems = append(health.Problems, "node is cordoned")
+	}
+
+	return health
 }
 
 // Logs returns logs from a pod
@@ -140,9 +346,30 @@ func (h *PodHandler) Logs(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
+	// Multi-container pods need an explicit container; default to the first one
+	container, err = resolveContainer(ctx, h.k8s, namespace, name, container)
+	if err != nil {
+		return nil, err
+	}
+
+	// With ?previous=true, read the instance before the last restart, like kubectl logs --previous
+	previous := ctx.Param("previous") == "true"
+	if previous {
+		pod, err := client.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod: %w", err)
+		}
+		if restartCount(pod, container) == 0 {
+			return nil, fmt.Errorf("no previous instance for container %s", container)
+		}
+	}
+
+	// With ?timestamps=true each line starts with its RFC3339Nano kubelet timestamp
 	opts := &corev1.PodLogOptions{
-		Container: container,
-		TailLines: &tailLines,
+		Container:  container,
+		TailLines:  &tailLines,
+		Previous:   previous,
+		Timestamps: ctx.Param("timestamps") == "true",
 	}
 
 	req := client.CoreV1().Pods(namespace).GetLogs(name, opts)
@@ -157,7 +384,7 @@ func (h *PodHandler) Logs(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	return map[string]string{"logs": string(logs)}, nil
+	return map[string]string{"logs": string(logs), "container": container}, nil
 }
 
 // Delete deletes a pod (effectively restarting it if managed by a controller)
@@ -203,6 +430,7 @@ func podToInfo(pod *corev1.Pod, detailed bool) PodInfo {
 			containers = append(containers, ContainerInfo{
 				Name:         cs.Name,
 				Image:        cs.Image,
+				ImageID:      cs.ImageID,
 				Ready:        cs.Ready,
 				RestartCount: cs.RestartCount,
 				State:        state,
@@ -223,15 +451,18 @@ func podToInfo(pod *corev1.Pod, detailed bool) PodInfo {
 	}
 
 	info := PodInfo{
-		Name:      pod.Name,
-		Namespace: pod.Namespace,
-		Status:    string(pod.Status.Phase),
-		Ready:     fmt.Sprintf("%d/%d", ready, total),
-		Restarts:  restarts,
-		Age:       formatAge(pod.CreationTimestamp.Time),
-		Node:      pod.Spec.NodeName,
-		IP:        pod.Status.PodIP,
-		Ports:     ports,
+		Name:              pod.Name,
+		Namespace:         pod.Namespace,
+		Status:            string(pod.Status.Phase),
+		Ready:             fmt.Sprintf("%d/%d", ready, total),
+		Restarts:          restarts,
+		Age:               formatAge(pod.CreationTimestamp.Time),
+		Node:              pod.Spec.NodeName,
+		IP:                pod.Status.PodIP,
+		Ports:             ports,
+		PriorityClassName: pod.Spec.PriorityClassName,
+		Priority:          pod.Spec.Priority,
+		RuntimeClassName:  runtimeClassName(pod),
 	}
 
 	if detailed {
@@ -242,6 +473,325 @@ func podToInfo(pod *corev1.Pod, detailed bool) PodInfo {
 	return info
 }
 
+// PodStatusSummary is a compact per-container status for cheap polling
+type PodStatusSummary struct {
+	Name       string                   `json:"name"`
+	Phase      string                   `json:"phase"`
+	Containers []ContainerStatusSummary `json:"containers"`
+}
+
+type ContainerStatusSummary struct {
+	Name           string           `json:"name"`
+	Init           bool             `json:"init,omitempty"`
+	Ready          bool             `json:"ready"`
+	State          string           `json:"state"`
+	Restarts       int32            `json:"restarts"`
+	LastTerminated *LastTermination `json:"lastTerminated,omitempty"`
+}
+
+type LastTermination struct {
+	Reason     string `json:"reason"`
+	ExitCode   int32  `json:"exitCode"`
+	FinishedAt string `json:"finishedAt"`
+}
+
+// Status returns just the pod phase and each container's ready/state/restarts
+func (h *PodHandler) Status(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return podStatusSummary(pod), nil
+}
+
+func podStatusSummary(pod *corev1.Pod) PodStatusSummary {
+	result := PodStatusSummary{
+		Name:       pod.Name,
+		Phase:      string(pod.Status.Phase),
+		Containers: []ContainerStatusSummary{},
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		summary := containerStatusSummary(cs)
+		summary.Init = true
+		result.Containers = append(result.Containers, summary)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		result.Containers = append(result.Containers, containerStatusSummary(cs))
+	}
+
+	return result
+}
+
+func containerStatusSummary(cs corev1.ContainerStatus) ContainerStatusSummary {
+	state := "unknown"
+	if cs.State.Running != nil {
+		state = "running"
+	} else if cs.State.Waiting != nil {
+		state = cs.State.Waiting.Reason
+	} else if cs.State.Terminated != nil {
+		state = cs.State.Terminated.Reason
+	}
+
+	summary := ContainerStatusSummary{
+		Name:     cs.Name,
+		Ready:    cs.Ready,
+		State:    state,
+		Restarts: cs.RestartCount,
+	}
+	if last := cs.LastTerminationState.Terminated; last != nil {
+		summary.LastTerminated = &LastTermination{
+			Reason:     last.Reason,
+			ExitCode:   last.ExitCode,
+			FinishedAt: last.FinishedAt.Format(time.RFC3339),
+		}
+	}
+	return summary
+}
+
+// crashLoopRestartThreshold is the default restart count that flags a recently restarted container
+const crashLoopRestartThreshold = 5
+
+// CrashLoopingContainer is a container that is crash looping or restarting frequently
+type CrashLoopingContainer struct {
+	Pod                  string `json:"pod"`
+	Namespace            string `json:"namespace"`
+	Container            string `json:"container"`
+	Restarts             int32  `json:"restarts"`
+	State                string `json:"state"`
+	LastTerminatedReason string `json:"lastTerminatedReason,omitempty"`
+	LastExitCode         int32  `json:"lastExitCode,omitempty"`
+	LastTerminatedAt     string `json:"lastTerminatedAt,omitempty"`
+}
+
+// ListCrashLooping returns containers in CrashLoopBackOff or with at least
+// threshold restarts whose last restart was within the past hour, most restarts first
+func (h *PodHandler) ListCrashLooping(ctx *gofr.Context) (interface{}, error) {
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	threshold := int32(crashLoopRestartThreshold)
+	if thresholdParam := ctx.Param("threshold"); thresholdParam != "" {
+		n, err := strconv.ParseInt(thresholdParam, 10, 32)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid threshold %q", thresholdParam)
+		}
+		threshold = int32(n)
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []CrashLoopingContainer{}
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			last := cs.LastTerminationState.Terminated
+			crashLooping := cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff"
+			restartingOften := cs.RestartCount >= threshold && last != nil && time.Since(last.FinishedAt.Time) < time.Hour
+			if !crashLooping && !restartingOften {
+				continue
+			}
+
+			summary := containerStatusSummary(cs)
+			entry := CrashLoopingContainer{
+				Pod:       pod.Name,
+				Namespace: pod.Namespace,
+				Container: cs.Name,
+				Restarts:  cs.RestartCount,
+				State:     summary.State,
+			}
+			if summary.LastTerminated != nil {
+				entry.LastTerminatedReason = summary.LastTerminated.Reason
+				entry.LastExitCode = summary.LastTerminated.ExitCode
+				entry.LastTerminatedAt = summary.LastTerminated.FinishedAt
+			}
+			result = append(result, entry)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Restarts > result[j].Restarts
+	})
+
+	return result, nil
+}
+
+// oomKillWindow is the default ?since window for ListOOMKilled
+const oomKillWindow = 24 * time.Hour
+
+// OOMKilledContainer is a container whose current or last run ended in an OOM kill
+type OOMKilledContainer struct {
+	Pod           string `json:"pod"`
+	Namespace     string `json:"namespace"`
+	Container     string `json:"container"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"` // Empty when the container has no limit
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	Restarts      int32  `json:"restarts"`
+	Current       bool   `json:"current"` // Still terminated, e.g. a job pod that won't restart
+	KilledAt      string `json:"killedAt"`
+	Age           string `json:"age"`
+
+	killedAt time.Time
+}
+
+// ListOOMKilled returns containers OOMKilled within ?since (default 24h), most
+// recent first; namespace=* lists the whole cluster. Only the current and last
+// state are kept by the kubelet, so earlier kills of the same container aren't visible.
+func (h *PodHandler) ListOOMKilled(ctx *gofr.Context) (interface{}, error) {
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	window := oomKillWindow
+	if sinceParam := ctx.Param("since"); sinceParam != "" {
+		d, err := time.ParseDuration(sinceParam)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid since %q", sinceParam)
+		}
+		window = d
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []OOMKilledContainer{}
+	for _, pod := range pods.Items {
+		specs := make(map[string]corev1.Container)
+		for _, c := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			specs[c.Name] = c
+		}
+
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			terminated, current := cs.State.Terminated, true
+			if terminated == nil || terminated.Reason != "OOMKilled" {
+				terminated, current = cs.LastTerminationState.Terminated, false
+			}
+			if terminated == nil || terminated.Reason != "OOMKilled" || time.Since(terminated.FinishedAt.Time) > window {
+				continue
+			}
+
+			entry := OOMKilledContainer{
+				Pod:       pod.Name,
+				Namespace: pod.Namespace,
+				Container: cs.Name,
+				Restarts:  cs.RestartCount,
+				Current:   current,
+				KilledAt:  terminated.FinishedAt.Format(time.RFC3339),
+				Age:       formatAge(terminated.FinishedAt.Time),
+				killedAt:  terminated.FinishedAt.Time,
+			}
+			if spec, ok := specs[cs.Name]; ok {
+				if limit, ok := spec.Resources.Limits[corev1.ResourceMemory]; ok {
+					entry.MemoryLimit = limit.String()
+				}
+				if request, ok := spec.Resources.Requests[corev1.ResourceMemory]; ok {
+					entry.MemoryRequest = request.String()
+				}
+			}
+			result = append(result, entry)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].killedAt.After(result[j].killedAt)
+	})
+
+	return result, nil
+}
+
+// ImageUsage is a container image and where it runs
+type ImageUsage struct {
+	Image      string   `json:"image"`
+	Pods       int      `json:"pods"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// ListImages aggregates the unique container images across all pods, most used
+// first. Unlike the resource lists it covers the whole cluster unless a namespace
+// is passed explicitly; the session and context namespaces don't apply.
+func (h *PodHandler) ListImages(ctx *gofr.Context) (interface{}, error) {
+	namespace := ""
+	if scope := resolveNamespace(ctx, h.k8s); scope.Source == "param" {
+		namespace = scope.Namespace
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]*ImageUsage)
+	namespaces := make(map[string]map[string]bool)
+	for _, pod := range pods.Items {
+		// Count each image once per pod, including init containers
+		seen := make(map[string]bool)
+		var images []string
+		for _, c := range pod.Spec.InitContainers {
+			images = append(images, c.Image)
+		}
+		for _, c := range pod.Spec.Containers {
+			images = append(images, c.Image)
+		}
+
+		for _, image := range images {
+			if seen[image] {
+				continue
+			}
+			seen[image] = true
+
+			if usage[image] == nil {
+				usage[image] = &ImageUsage{Image: image}
+				namespaces[image] = make(map[string]bool)
+			}
+			usage[image].Pods++
+			namespaces[image][pod.Namespace] = true
+		}
+	}
+
+	result := make([]ImageUsage, 0, len(usage))
+	for image, u := range usage {
+		for ns := range namespaces[image] {
+			u.Namespaces = append(u.Namespaces, ns)
+		}
+		sort.Strings(u.Namespaces)
+		result = append(result, *u)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Pods != result[j].Pods {
+			return result[i].Pods > result[j].Pods
+		}
+		return result[i].Image < result[j].Image
+	})
+
+	return result, nil
+}
+
 // Events returns events for a specific pod
 func (h *PodHandler) Events(ctx *gofr.Context) (interface{}, error) {
 	namespace := ctx.PathParam("namespace")
@@ -254,9 +804,9 @@ func (h *PodHandler) Events(ctx *gofr.Context) (interface{}, error) {
 
 	// Get events filtered by the pod
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=Pod", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -325,8 +875,10 @@ func fetchPodMetrics(metricsClient *metricsv.Clientset, namespace, name string)
 	return result
 }
 
-// podToInfoWithMetrics converts a pod to PodInfo with metrics data
-func podToInfoWithMetrics(pod *corev1.Pod, metrics map[string]ContainerResource, client kubernetes.Interface, namespace string) PodInfo {
+// podToInfoWithMetrics converts a pod to PodInfo with metrics data. Env values
+// from Secrets are left out, keeping only the secret:name/key reference, when
+// the filter disables secrets.
+func podToInfoWithMetrics(pod *corev1.Pod, metrics map[string]ContainerResource, client kubernetes.Interface, namespace string, filter *ResourceFilter) PodInfo {
 	ready := 0
 	total := len(pod.Spec.Containers)
 	var restarts int32
@@ -413,7 +965,7 @@ func podToInfoWithMetrics(pod *corev1.Pod, metrics map[string]ContainerResource,
 				prefix := ef.Prefix
 				secretName := ef.SecretRef.Name
 				// Try to fetch the Secret and expand keys with values
-				if client != nil {
+				if client != nil && filter.Enabled("secrets") {
 					secret, err := client.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
 					if err == nil {
 						for key, value := range secret.Data {
@@ -434,6 +986,8 @@ func podToInfoWithMetrics(pod *corev1.Pod, metrics map[string]ContainerResource,
 			}
 		}
 
+		fromCount := len(envVars)
+
 		// Get environment variables
 		for _, e := range spec.Env {
 			ev := EnvVar{Name: e.Name}
@@ -458,7 +1012,7 @@ func podToInfoWithMetrics(pod *corev1.Pod, metrics map[string]ContainerResource,
 					secretKey := e.ValueFrom.SecretKeyRef.Key
 					ev.ValueFrom = fmt.Sprintf("secret:%s/%s", secretName, secretKey)
 					// Fetch actual value from Secret
-					if client != nil {
+					if client != nil && filter.Enabled("secrets") {
 						secret, err := client.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
 						if err == nil {
 							if val, ok := secret.Data[secretKey]; ok {
@@ -468,8 +1022,15 @@ func podToInfoWithMetrics(pod *corev1.Pod, metrics map[string]ContainerResource,
 					}
 				} else if e.ValueFrom.FieldRef != nil {
 					ev.ValueFrom = fmt.Sprintf("field:%s", e.ValueFrom.FieldRef.FieldPath)
+					// Resolve downward API fields from the live pod
+					if val, ok := resolveFieldRef(pod, e.ValueFrom.FieldRef.FieldPath); ok {
+						ev.Value = val
+					}
 				} else if e.ValueFrom.ResourceFieldRef != nil {
 					ev.ValueFrom = fmt.Sprintf("resource:%s", e.ValueFrom.ResourceFieldRef.Resource)
+					if val, ok := resolveResourceFieldRef(containerSpecs, cs.Name, e.ValueFrom.ResourceFieldRef); ok {
+						ev.Value = val
+					}
 				}
 			}
 			envVars = append(envVars, ev)
@@ -478,25 +1039,367 @@ func podToInfoWithMetrics(pod *corev1.Pod, metrics map[string]ContainerResource,
 		containers = append(containers, ContainerInfo{
 			Name:         cs.Name,
 			Image:        cs.Image,
+			ImageID:      cs.ImageID,
 			Ready:        cs.Ready,
 			RestartCount: cs.RestartCount,
 			State:        state,
 			Ports:        ports,
 			Resources:    resources,
 			Env:          envVars,
+			Lifecycle:    containerLifecycle(spec.Lifecycle),
+			StartupProbe: describeProbe(spec.StartupProbe),
+			Started:      cs.Started,
+			EnvConflicts: envConflicts(envVars, fromCount),
 		})
 	}
 
 	return PodInfo{
-		Name:       pod.Name,
-		Namespace:  pod.Namespace,
-		Status:     string(pod.Status.Phase),
-		Ready:      fmt.Sprintf("%d/%d", ready, total),
-		Restarts:   restarts,
-		Age:        formatAge(pod.CreationTimestamp.Time),
-		Node:       pod.Spec.NodeName,
-		IP:         pod.Status.PodIP,
-		Containers: containers,
-		Labels:     pod.Labels,
+		Name:              pod.Name,
+		Namespace:         pod.Namespace,
+		Status:            string(pod.Status.Phase),
+		Ready:             fmt.Sprintf("%d/%d", ready, total),
+		Restarts:          restarts,
+		Age:               formatAge(pod.CreationTimestamp.Time),
+		Node:              pod.Spec.NodeName,
+		IP:                pod.Status.PodIP,
+		Containers:        containers,
+		Labels:            pod.Labels,
+		PriorityClassName: pod.Spec.PriorityClassName,
+		Priority:          pod.Spec.Priority,
+		RuntimeClassName:  runtimeClassName(pod),
+		Finalizers:        pod.Finalizers,
+		DeletionTimestamp: formatDeletionTimestamp(pod.DeletionTimestamp),
+
+		TerminationGracePeriodSeconds: pod.Spec.TerminationGracePeriodSeconds,
+
+		Conditions:     podConditions(pod),
+		ReadinessGates: readinessGates(pod),
+		NotReadyReason: notReadyReason(pod),
+
+		InitContainers:      initContainerInfos(pod),
+		EphemeralContainers: ephemeralContainerInfos(pod),
+	}
+}
+
+// initContainerInfos lists init containers in spec order, including those not started yet
+func initContainerInfos(pod *corev1.Pod) []ContainerInfo {
+	var containers []ContainerInfo
+	for _, c := range pod.Spec.InitContainers {
+		containers = append(containers, containerStatusInfo(c.Name, c.Image, pod.Status.InitContainerStatuses))
+	}
+	return containers
+}
+
+func ephemeralContainerInfos(pod *corev1.Pod) []ContainerInfo {
+	var containers []ContainerInfo
+	for _, c := range pod.Spec.EphemeralContainers {
+		containers = append(containers, containerStatusInfo(c.Name, c.Image, pod.Status.EphemeralContainerStatuses))
+	}
+	return containers
+}
+
+// containerStatusInfo describes a container from its status, or as "pending"
+// with the spec image if the kubelet hasn't reported it yet
+func containerStatusInfo(name, image string, statuses []corev1.ContainerStatus) ContainerInfo {
+	info := ContainerInfo{Name: name, Image: image, State: "pending"}
+	for _, cs := range statuses {
+		if cs.Name == name {
+			info.Image = cs.Image
+			info.ImageID = cs.ImageID
+			info.Ready = cs.Ready
+			info.RestartCount = cs.RestartCount
+			info.State = containerStatusSummary(cs).State
+			break
+		}
+	}
+	return info
+}
+
+func podConditions(pod *corev1.Pod) []PodCondition {
+	var conditions []PodCondition
+	for _, c := range pod.Status.Conditions {
+		conditions = append(conditions, PodCondition{
+			Type:    string(c.Type),
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+	}
+	return conditions
+}
+
+// podLifecycleConditions are the pod conditions in the order a pod passes them
+var podLifecycleConditions = []corev1.PodConditionType{
+	corev1.PodScheduled,
+	corev1.PodInitialized,
+	corev1.ContainersReady,
+	corev1.PodReady,
+}
+
+// notReadyReason explains why a pod isn't Ready from the first lifecycle
+// condition that hasn't passed, or "" if the pod is Ready or has completed
+func notReadyReason(pod *corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return ""
+	}
+
+	conditions := make(map[corev1.PodConditionType]corev1.PodCondition)
+	for _, c := range pod.Status.Conditions {
+		conditions[c.Type] = c
+	}
+	if c, ok := conditions[corev1.PodReady]; ok && c.Status == corev1.ConditionTrue {
+		return ""
+	}
+
+	for _, conditionType := range podLifecycleConditions {
+		c, ok := conditions[conditionType]
+		if !ok {
+			if conditionType == corev1.PodScheduled {
+				return "PodScheduled: waiting for the scheduler"
+			}
+			continue
+		}
+		if c.Status == corev1.ConditionTrue {
+			continue
+		}
+
+		reason := fmt.Sprintf("%s=%s", c.Type, c.Status)
+		switch {
+		case conditionType == corev1.PodInitialized || conditionType == corev1.ContainersReady:
+			// The condition only says which containers; their states say why
+			if details := unreadyContainers(pod, conditionType == corev1.PodInitialized); details != "" {
+				return reason + ": " + details
+			}
+		case conditionType == corev1.PodReady:
+			// Containers are ready, so a readiness gate is holding the pod back
+			for _, gate := range readinessGates(pod) {
+				if gate.Status != string(corev1.ConditionTrue) {
+					return fmt.Sprintf("%s: readiness gate %s is %s", reason, gate.ConditionType, gate.Status)
+				}
+			}
+		}
+		if c.Message != "" {
+			return reason + ": " + c.Message
+		}
+		if c.Reason != "" {
+			return reason + ": " + c.Reason
+		}
+		return reason
+	}
+	return ""
+}
+
+// unreadyContainers lists init or app containers that aren't done or ready, with their state
+func unreadyContainers(pod *corev1.Pod, init bool) string {
+	statuses := pod.Status.ContainerStatuses
+	if init {
+		statuses = pod.Status.InitContainerStatuses
+	}
+
+	// Native sidecars are init containers that keep running; they only need to have started
+	sidecars := make(map[string]bool)
+	for _, c := range pod.Spec.InitContainers {
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			sidecars[c.Name] = true
+		}
+	}
+
+	var parts []string
+	for _, cs := range statuses {
+		if init && cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0 {
+			continue
+		}
+		if init && sidecars[cs.Name] && cs.Started != nil && *cs.Started {
+			continue
+		}
+		if !init && cs.Ready {
+			continue
+		}
+		state := containerStatusSummary(cs).State
+		if cs.State.Running != nil && !cs.Ready {
+			state = "running, not ready"
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", cs.Name, state))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// readinessGates pairs each readiness gate with the status of its condition
+func readinessGates(pod *corev1.Pod) []ReadinessGateStatus {
+	var gates []ReadinessGateStatus
+	for _, gate := range pod.Spec.ReadinessGates {
+		status := ReadinessGateStatus{ConditionType: string(gate.ConditionType), Status: "Missing"}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == gate.ConditionType {
+				status.Status = string(c.Status)
+				break
+			}
+		}
+		gates = append(gates, status)
+	}
+	return gates
+}
+
+// describeProbe summarizes a probe as its action and timing, e.g.
+// "httpGet :8080/healthz every 10s, 30 failures allowed", or "" if unset
+func describeProbe(probe *corev1.Probe) string {
+	if probe == nil {
+		return ""
+	}
+
+	action := "unknown"
+	switch {
+	case probe.Exec != nil:
+		action = "exec " + strings.Join(probe.Exec.Command, " ")
+	case probe.HTTPGet != nil:
+		action = fmt.Sprintf("httpGet :%s%s", probe.HTTPGet.Port.String(), probe.HTTPGet.Path)
+	case probe.TCPSocket != nil:
+		action = fmt.Sprintf("tcpSocket :%s", probe.TCPSocket.Port.String())
+	case probe.GRPC != nil:
+		action = fmt.Sprintf("grpc :%d", probe.GRPC.Port)
+	}
+
+	// Zero values mean the API defaults: every 10s, 3 failures
+	period := probe.PeriodSeconds
+	if period == 0 {
+		period = 10
+	}
+	failures := probe.FailureThreshold
+	if failures == 0 {
+		failures = 3
+	}
+	return fmt.Sprintf("%s every %ds, %d failures allowed", action, period, failures)
+}
+
+// containerLifecycle summarizes a container's lifecycle hooks, or nil if it has none
+func containerLifecycle(lifecycle *corev1.Lifecycle) *LifecycleHooks {
+	if lifecycle == nil || (lifecycle.PostStart == nil && lifecycle.PreStop == nil) {
+		return nil
+	}
+	return &LifecycleHooks{
+		PostStart: lifecycleHandler(lifecycle.PostStart),
+		PreStop:   lifecycleHandler(lifecycle.PreStop),
+	}
+}
+
+func lifecycleHandler(handler *corev1.LifecycleHandler) *LifecycleHandler {
+	switch {
+	case handler == nil:
+		return nil
+	case handler.Exec != nil:
+		return &LifecycleHandler{Type: "exec", Action: strings.Join(handler.Exec.Command, " ")}
+	case handler.HTTPGet != nil:
+		scheme := strings.ToLower(string(handler.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		host := handler.HTTPGet.Host
+		if host == "" {
+			host = "<pod-ip>"
+		}
+		return &LifecycleHandler{Type: "httpGet", Action: fmt.Sprintf("%s://%s:%s%s", scheme, host, handler.HTTPGet.Port.String(), handler.HTTPGet.Path)}
+	case handler.TCPSocket != nil:
+		host := handler.TCPSocket.Host
+		if host == "" {
+			host = "<pod-ip>"
+		}
+		return &LifecycleHandler{Type: "tcpSocket", Action: fmt.Sprintf("%s:%s", host, handler.TCPSocket.Port.String())}
+	case handler.Sleep != nil:
+		return &LifecycleHandler{Type: "sleep", Action: fmt.Sprintf("%ds", handler.Sleep.Seconds)}
+	}
+	return &LifecycleHandler{Type: "unknown"}
+}
+
+// runtimeClassName returns the pod's RuntimeClass, or "" for the cluster default runtime
+func runtimeClassName(pod *corev1.Pod) string {
+	if pod.Spec.RuntimeClassName == nil {
+		return ""
+	}
+	return *pod.Spec.RuntimeClassName
+}
+
+// resolveFieldRef returns the value the downward API exposes for a pod field path
+func resolveFieldRef(pod *corev1.Pod, fieldPath string) (string, bool) {
+	switch fieldPath {
+	case "metadata.name":
+		return pod.Name, true
+	case "metadata.namespace":
+		return pod.Namespace, true
+	case "metadata.uid":
+		return string(pod.UID), true
+	case "spec.nodeName":
+		return pod.Spec.NodeName, pod.Spec.NodeName != ""
+	case "spec.serviceAccountName":
+		return pod.Spec.ServiceAccountName, true
+	case "status.hostIP":
+		return pod.Status.HostIP, pod.Status.HostIP != ""
+	case "status.podIP":
+		return pod.Status.PodIP, pod.Status.PodIP != ""
+	case "status.hostIPs":
+		var ips []string
+		for _, ip := range pod.Status.HostIPs {
+			ips = append(ips, ip.IP)
+		}
+		return strings.Join(ips, ","), len(ips) > 0
+	case "status.podIPs":
+		var ips []string
+		for _, ip := range pod.Status.PodIPs {
+			ips = append(ips, ip.IP)
+		}
+		return strings.Join(ips, ","), len(ips) > 0
+	}
+
+	// metadata.labels['key'] and metadata.annotations['key']
+	if key, ok := fieldPathKey(fieldPath, "metadata.labels"); ok {
+		val, found := pod.Labels[key]
+		return val, found
+	}
+	if key, ok := fieldPathKey(fieldPath, "metadata.annotations"); ok {
+		val, found := pod.Annotations[key]
+		return val, found
+	}
+
+	return "", false
+}
+
+// fieldPathKey extracts key from a subscripted field path like prefix['key']
+func fieldPathKey(fieldPath, prefix string) (string, bool) {
+	rest, ok := strings.CutPrefix(fieldPath, prefix+"['")
+	if !ok {
+		return "", false
+	}
+	return strings.CutSuffix(rest, "']")
+}
+
+// resolveResourceFieldRef returns a container's request or limit scaled by the
+// divisor, rounded up as the kubelet does. Unset limits default to node
+// allocatable at runtime, which isn't knowable here, so they are left unresolved.
+func resolveResourceFieldRef(containerSpecs map[string]corev1.Container, containerName string, ref *corev1.ResourceFieldSelector) (string, bool) {
+	if ref.ContainerName != "" {
+		containerName = ref.ContainerName
+	}
+	spec, ok := containerSpecs[containerName]
+	if !ok {
+		return "", false
+	}
+
+	var quantity resource.Quantity
+	if name, found := strings.CutPrefix(ref.Resource, "limits."); found {
+		quantity, ok = spec.Resources.Limits[corev1.ResourceName(name)]
+	} else if name, found := strings.CutPrefix(ref.Resource, "requests."); found {
+		quantity, ok = spec.Resources.Requests[corev1.ResourceName(name)]
+	}
+	if !ok {
+		return "", false
+	}
+
+	divisor := resource.MustParse("1")
+	if !ref.Divisor.IsZero() {
+		divisor = ref.Divisor
+	}
+
+	if strings.HasSuffix(ref.Resource, ".cpu") {
+		return strconv.FormatInt(int64(math.Ceil(float64(quantity.MilliValue())/float64(divisor.MilliValue()))), 10), true
 	}
+	return strconv.FormatInt(int64(math.Ceil(float64(quantity.Value())/float64(divisor.Value()))), 10), true
 }
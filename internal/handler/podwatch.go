@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// PodWatchHandler streams a single pod's status over SSE so detail pages stay fresh without polling
+type PodWatchHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewPodWatchHandler(k8s *service.K8sManager) *PodWatchHandler {
+	return &PodWatchHandler{k8s: k8s}
+}
+
+// Middleware serves GET /api/pods/{namespace}/{name}/watch as an SSE stream
+func (h *PodWatchHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/pods/") {
+			parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/pods/"), "/")
+			if len(parts) == 3 && parts[2] == "watch" {
+				h.HandleWatch(w, r, parts[0], parts[1])
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleWatch sends the pod's current status, then a message each time its
+// phase, container states or restarts change. A "deleted" message ends the stream.
+func (h *PodWatchHandler) HandleWatch(w http.ResponseWriter, r *http.Request, namespace, name string) {
+	client, err := h.k8s.GetStreamingClient()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ctx := r.Context()
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+
+	// Check the pod exists before switching to SSE so a typo gets a plain 404
+	list, err := client.CoreV1().Pods(namespace).List(ctx, listOptions(metav1.ListOptions{FieldSelector: fieldSelector}))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(list.Items) == 0 {
+		http.Error(w, fmt.Sprintf("pod %s/%s not found", namespace, name), http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	last := podStatusSummary(&list.Items[0])
+	sendSSEMessage(w, flusher, SSEMessage{Type: "update", Resource: "pods", Namespace: namespace, Data: last})
+	resourceVersion := list.ResourceVersion
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		watcher, err := client.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fieldSelector,
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "pods", Namespace: namespace, Data: err.Error()})
+			return
+		}
+
+		expired := false
+	consume:
+		for {
+			select {
+			case <-ctx.Done():
+				watcher.Stop()
+				return
+			case <-keepAlive.C:
+				fmt.Fprint(w, ": keep-alive\n\n")
+				flusher.Flush()
+			case ev, ok := <-watcher.ResultChan():
+				if !ok {
+					break consume
+				}
+
+				switch ev.Type {
+				case watch.Error:
+					// Typically 410 Gone: our resourceVersion is too old, restart from now
+					expired = true
+					break consume
+				case watch.Deleted:
+					watcher.Stop()
+					sendSSEMessage(w, flusher, SSEMessage{Type: "deleted", Resource: "pods", Namespace: namespace, Data: name})
+					return
+				case watch.Added, watch.Modified:
+					pod, ok := ev.Object.(*corev1.Pod)
+					if !ok {
+						continue
+					}
+					resourceVersion = pod.ResourceVersion
+
+					// Skip changes that don't affect status, e.g. label or annotation edits
+					summary := podStatusSummary(pod)
+					if reflect.DeepEqual(summary, last) {
+						continue
+					}
+					last = summary
+					sendSSEMessage(w, flusher, SSEMessage{Type: "update", Resource: "pods", Namespace: namespace, Data: summary})
+				}
+			}
+		}
+		watcher.Stop()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if expired {
+			list, err := client.CoreV1().Pods(namespace).List(ctx, listOptions(metav1.ListOptions{FieldSelector: fieldSelector}))
+			if err != nil {
+				sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "pods", Namespace: namespace, Data: err.Error()})
+				return
+			}
+			if len(list.Items) == 0 {
+				sendSSEMessage(w, flusher, SSEMessage{Type: "deleted", Resource: "pods", Namespace: namespace, Data: name})
+				return
+			}
+			resourceVersion = list.ResourceVersion
+
+			// Changes may have been missed while the watch was expired
+			if summary := podStatusSummary(&list.Items[0]); !reflect.DeepEqual(summary, last) {
+				last = summary
+				sendSSEMessage(w, flusher, SSEMessage{Type: "update", Resource: "pods", Namespace: namespace, Data: summary})
+			}
+		}
+	}
+}
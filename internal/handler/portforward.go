@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -17,9 +18,11 @@ import (
 
 // PortForwardHandler handles port forwarding requests
 type PortForwardHandler struct {
-	k8sManager *service.K8sManager
-	forwards   map[string]*activeForward
-	mu         sync.RWMutex
+	k8sManager   *service.K8sManager
+	forwards     map[string]*activeForward
+	history      []PortForwardInfo // recently closed forwards, newest first
+	historyLimit int
+	mu           sync.RWMutex
 }
 
 type activeForward struct {
@@ -28,6 +31,7 @@ type activeForward struct {
 	PodName     string   `json:"podName"`
 	LocalPort   int      `json:"localPort"`
 	RemotePort  int      `json:"remotePort"`
+	startedAt   time.Time
 	stopChan    chan struct{}
 	readyChan   chan struct{}
 }
@@ -39,13 +43,63 @@ type PortForwardInfo struct {
 	PodName    string `json:"podName"`
 	LocalPort  int    `json:"localPort"`
 	RemotePort int    `json:"remotePort"`
+	// Lifecycle fields
+	Status      string `json:"status,omitempty"` // "active" or "closed"
+	StartedAt   string `json:"startedAt,omitempty"`
+	ClosedAt    string `json:"closedAt,omitempty"`
+	CloseReason string `json:"closeReason,omitempty"`
 }
 
-// NewPortForwardHandler creates a new port forward handler
-func NewPortForwardHandler(k8sManager *service.K8sManager) *PortForwardHandler {
+// NewPortForwardHandler creates a new port forward handler that remembers
+// up to historyLimit recently closed forwards
+func NewPortForwardHandler(k8sManager *service.K8sManager, historyLimit int) *PortForwardHandler {
+	if historyLimit < 0 {
+		historyLimit = 0
+	}
 	return &PortForwardHandler{
-		k8sManager: k8sManager,
-		forwards:   make(map[string]*activeForward),
+		k8sManager:   k8sManager,
+		forwards:     make(map[string]*activeForward),
+		historyLimit: historyLimit,
+	}
+}
+
+// closeForward removes an active forward and records it in the history.
+// It returns the removed forward, or nil if it was already closed.
+func (h *PortForwardHandler) closeForward(forwardID, reason string) *activeForward {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	forward, exists := h.forwards[forwardID]
+	if !exists {
+		return nil
+	}
+	delete(h.forwards, forwardID)
+
+	if h.historyLimit == 0 {
+		return forward
+	}
+
+	info := forward.toInfo()
+	info.Status = "closed"
+	info.ClosedAt = time.Now().Format(time.RFC3339)
+	info.CloseReason = reason
+
+	h.history = append([]PortForwardInfo{info}, h.history...)
+	if len(h.history) > h.historyLimit {
+		h.history = h.history[:h.historyLimit]
+	}
+	return forward
+}
+
+func (f *activeForward) toInfo() PortForwardInfo {
+	return PortForwardInfo{
+		ID:         f.ID,
+		Namespace:  f.Namespace,
+		PodName:    f.PodName,
+		LocalPort:  f.LocalPort,
+		RemotePort: f.RemotePort,
+		Status:     "active",
+		StartedAt:  f.startedAt.Format(time.RFC3339),
 	}
 }
 
@@ -94,7 +148,9 @@ func (h *PortForwardHandler) Start(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	// Verify pod exists
-	_, err = client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	lookupCtx, cancelLookup := context.WithTimeout(ctx, podLookupTimeout)
+	_, err = client.CoreV1().Pods(namespace).Get(lookupCtx, name, metav1.GetOptions{})
+	cancelLookup()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pod: %w", err)
 	}
@@ -132,6 +188,7 @@ func (h *PortForwardHandler) Start(ctx *gofr.Context) (interface{}, error) {
 		PodName:    name,
 		LocalPort:  req.LocalPort,
 		RemotePort: req.RemotePort,
+		startedAt:  time.Now(),
 		stopChan:   stopChan,
 		readyChan:  readyChan,
 	}
@@ -145,14 +202,14 @@ func (h *PortForwardHandler) Start(ctx *gofr.Context) (interface{}, error) {
 
 	// Start port forwarding in background
 	go func() {
+		reason := "connection closed"
 		if err := pf.ForwardPorts(); err != nil {
 			ctx.Logger.Errorf("Port forward error: %v", err)
+			reason = fmt.Sprintf("error: %v", err)
 			errChan <- err
 		}
 		// Clean up when done
-		h.mu.Lock()
-		delete(h.forwards, forwardID)
-		h.mu.Unlock()
+		h.closeForward(forwardID, reason)
 	}()
 
 	// Wait for ready with timeout
@@ -164,20 +221,13 @@ func (h *PortForwardHandler) Start(ctx *gofr.Context) (interface{}, error) {
 		return nil, fmt.Errorf("port forward failed: %w", err)
 	case <-time.After(10 * time.Second):
 		// Timeout - clean up and return error
-		close(stopChan)
-		h.mu.Lock()
-		delete(h.forwards, forwardID)
-		h.mu.Unlock()
+		if h.closeForward(forwardID, "timed out waiting for forward to become ready") != nil {
+			close(stopChan)
+		}
 		return nil, fmt.Errorf("port forward timed out")
 	}
 
-	return PortForwardInfo{
-		ID:         forwardID,
-		Namespace:  namespace,
-		PodName:    name,
-		LocalPort:  req.LocalPort,
-		RemotePort: req.RemotePort,
-	}, nil
+	return forward.toInfo(), nil
 }
 
 // Stop stops a port forward
@@ -192,36 +242,30 @@ func (h *PortForwardHandler) Stop(ctx *gofr.Context) (interface{}, error) {
 
 	forwardID := fmt.Sprintf("%s/%s:%d:%d", namespace, name, localPort, remotePort)
 
-	h.mu.Lock()
-	forward, exists := h.forwards[forwardID]
-	if !exists {
-		h.mu.Unlock()
+	// Record the user stop before the forwarding goroutine notices the closed channel
+	forward := h.closeForward(forwardID, "stopped by user")
+	if forward == nil {
 		return nil, fmt.Errorf("port forward not found: %s", forwardID)
 	}
-
 	close(forward.stopChan)
-	delete(h.forwards, forwardID)
-	h.mu.Unlock()
 
 	return map[string]string{
 		"message": fmt.Sprintf("Stopped port forward %s", forwardID),
 	}, nil
 }
 
-// List lists all active port forwards
+// List lists all active port forwards, followed by recently closed ones when history=true
 func (h *PortForwardHandler) List(ctx *gofr.Context) (interface{}, error) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
 	var forwards []PortForwardInfo
 	for _, f := range h.forwards {
-		forwards = append(forwards, PortForwardInfo{
-			ID:         f.ID,
-			Namespace:  f.Namespace,
-			PodName:    f.PodName,
-			LocalPort:  f.LocalPort,
-			RemotePort: f.RemotePort,
-		})
+		forwards = append(forwards, f.toInfo())
+	}
+
+	if ctx.Param("history") == "true" {
+		forwards = append(forwards, h.history...)
 	}
 
 	return forwards, nil
@@ -238,13 +282,15 @@ func (h *PortForwardHandler) ListForPod(ctx *gofr.Context) (interface{}, error)
 	var forwards []PortForwardInfo
 	for _, f := range h.forwards {
 		if f.Namespace == namespace && f.PodName == name {
-			forwards = append(forwards, PortForwardInfo{
-				ID:         f.ID,
-				Namespace:  f.Namespace,
-				PodName:    f.PodName,
-				LocalPort:  f.LocalPort,
-				RemotePort: f.RemotePort,
-			})
+			forwards = append(forwards, f.toInfo())
+		}
+	}
+
+	if ctx.Param("history") == "true" {
+		for _, f := range h.history {
+			if f.Namespace == namespace && f.PodName == name {
+				forwards = append(forwards, f)
+			}
 		}
 	}
 
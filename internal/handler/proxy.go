@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+const (
+	// proxyTimeout bounds a single proxied request
+	proxyTimeout = 30 * time.Second
+	// maxProxyResponseSize caps the body relayed back to the browser
+	maxProxyResponseSize = 10 << 20
+)
+
+// proxyTargets are the resources whose proxy subresource can be reached
+var proxyTargets = map[string]bool{
+	"services": true,
+	"pods":     true,
+}
+
+// ProxyHandler fetches HTTP endpoints inside the cluster through the apiserver
+// proxy subresource, like kubectl get --raw, so /metrics or /healthz can be
+// checked without a port-forward
+type ProxyHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewProxyHandler(k8s *service.K8sManager) *ProxyHandler {
+	return &ProxyHandler{k8s: k8s}
+}
+
+// Middleware serves GET /api/proxy/{services|pods}/{namespace}/{name}/{port}/{path...}
+func (h *ProxyHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/proxy/") {
+			parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/proxy/"), "/", 5)
+			if len(parts) >= 4 && proxyTargets[parts[0]] {
+				path := ""
+				if len(parts) == 5 {
+					path = parts[4]
+				}
+				h.HandleProxy(w, r, parts[0], parts[1], parts[2], parts[3], path)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleProxy relays the upstream body as plain text. Serving it with the
+// upstream content type would run any HTML or script from the cluster on
+// kubeui's own origin, with access to the rest of the API. Query parameters are
+// passed through to the target.
+func (h *ProxyHandler) HandleProxy(w http.ResponseWriter, r *http.Request, resource, namespace, name, port, path string) {
+	if namespace == "" || name == "" || port == "" {
+		http.Error(w, "namespace, name and port are required", http.StatusBadRequest)
+		return
+	}
+	// client-go joins the URL with path.Join, which resolves dot segments, so
+	// ../../secrets/foo would escape the proxy subresource and read any object
+	if !validProxyPath(namespace, name, port, path) {
+		http.Error(w, "proxy path must not contain empty, . or .. segments", http.StatusBadRequest)
+		return
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), proxyTimeout)
+	defer cancel()
+
+	// The proxy subresource addresses a port as name:port, e.g. my-svc:8080 or my-svc:http
+	req := client.CoreV1().RESTClient().Get().
+		Namespace(namespace).
+		Resource(resource).
+		Name(name + ":" + port).
+		SubResource("proxy").
+		Suffix(path)
+	for key, values := range r.URL.Query() {
+		for _, value := range values {
+			req = req.Param(key, value)
+		}
+	}
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		// Non-2xx upstream responses come back as status errors carrying the code
+		statusCode := http.StatusBadGateway
+		var status apierrors.APIStatus
+		if errors.As(err, &status) && status.Status().Code != 0 {
+			statusCode = int(status.Status().Code)
+		}
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+	defer stream.Close()
+
+	// Read one byte past the limit to tell a truncated body from one that fits exactly
+	body, err := io.ReadAll(io.LimitReader(stream, maxProxyResponseSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	if len(body) > maxProxyResponseSize {
+		body = body[:maxProxyResponseSize]
+		w.Header().Set("X-Kubeui-Truncated", "true")
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// validProxyPath reports whether the proxy target and the path after the port
+// are free of empty, . and .. segments. An empty path proxies the target root.
+func validProxyPath(namespace, name, port, path string) bool {
+	segments := []string{namespace, name, port}
+	if path != "" {
+		segments = append(segments, strings.Split(path, "/")...)
+	}
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProxyMiddlewareRejectsTraversal(t *testing.T) {
+	h := NewProxyHandler(nil)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request for %s fell through to the next handler", r.URL.Path)
+	})
+
+	paths := []string{
+		"/api/proxy/services/ns/svc/80/../../../secrets/foo",
+		"/api/proxy/services/ns/svc/80/../../../../../secrets",
+		"/api/proxy/pods/ns/pod/8080/metrics/../../..",
+		"/api/proxy/services/ns/svc/80/./healthz",
+		"/api/proxy/services/ns/svc/80//healthz",
+		"/api/proxy/services/ns/../80/healthz",
+		"/api/proxy/services/../svc/80/healthz",
+		"/api/proxy/services/ns/svc/../healthz",
+	}
+	for _, path := range paths {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = path
+		rec := httptest.NewRecorder()
+		h.Middleware(next).ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status %d, want %d", path, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestValidProxyPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"", true},
+		{"metrics", true},
+		{"api/v1/status", true},
+		{"..", false},
+		{"metrics/..", false},
+		{"a/./b", false},
+		{"a//b", false},
+		{"metrics/", false},
+	}
+	for _, tt := range tests {
+		if got := validProxyPath("ns", "svc", "80", tt.path); got != tt.want {
+			t.Errorf("validProxyPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
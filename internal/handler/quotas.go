@@ -25,17 +25,27 @@ type ResourceQuotaInfo struct {
 	Hard      map[string]string `json:"hard"`
 	Used      map[string]string `json:"used"`
 	Age       string            `json:"age"`
+	// Scopes limit what the quota counts, e.g. only BestEffort pods or one PriorityClass
+	Scopes        []string             `json:"scopes,omitempty"`
+	ScopeSelector []QuotaScopeSelector `json:"scopeSelector,omitempty"`
+}
+
+// QuotaScopeSelector is one spec.scopeSelector.matchExpressions entry
+type QuotaScopeSelector struct {
+	ScopeName string   `json:"scopeName"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values,omitempty"`
 }
 
 func (h *QuotaHandler) ListResourceQuotas(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	quotas, err := client.CoreV1().ResourceQuotas(namespace).List(context.Background(), metav1.ListOptions{})
+	quotas, err := client.CoreV1().ResourceQuotas(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -52,13 +62,27 @@ func (h *QuotaHandler) ListResourceQuotas(ctx *gofr.Context) (interface{}, error
 			used[string(k)] = v.String()
 		}
 
-		result = append(result, ResourceQuotaInfo{
+		info := ResourceQuotaInfo{
 			Name:      quota.Name,
 			Namespace: quota.Namespace,
 			Hard:      hard,
 			Used:      used,
 			Age:       formatAge(quota.CreationTimestamp.Time),
-		})
+		}
+		for _, scope := range quota.Spec.Scopes {
+			info.Scopes = append(info.Scopes, string(scope))
+		}
+		if quota.Spec.ScopeSelector != nil {
+			for _, expr := range quota.Spec.ScopeSelector.MatchExpressions {
+				info.ScopeSelector = append(info.ScopeSelector, QuotaScopeSelector{
+					ScopeName: string(expr.ScopeName),
+					Operator:  string(expr.Operator),
+					Values:    expr.Values,
+				})
+			}
+		}
+
+		result = append(result, info)
 	}
 
 	return result, nil
@@ -72,14 +96,14 @@ type LimitRangeInfo struct {
 }
 
 func (h *QuotaHandler) ListLimitRanges(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	limitRanges, err := client.CoreV1().LimitRanges(namespace).List(context.Background(), metav1.ListOptions{})
+	limitRanges, err := client.CoreV1().LimitRanges(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
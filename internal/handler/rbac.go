@@ -2,8 +2,11 @@ package handler
 
 import (
 	"context"
+	"fmt"
 
 	"gofr.dev/pkg/gofr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/opengittr/kubeui/internal/service"
@@ -25,14 +28,14 @@ type ServiceAccountInfo struct {
 }
 
 func (h *RBACHandler) ListServiceAccounts(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	sas, err := client.CoreV1().ServiceAccounts(namespace).List(context.Background(), metav1.ListOptions{})
+	sas, err := client.CoreV1().ServiceAccounts(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -49,3 +52,162 @@ func (h *RBACHandler) ListServiceAccounts(ctx *gofr.Context) (interface{}, error
 
 	return result, nil
 }
+
+// ServiceAccountPermissions is what a service account can do, traced through its bindings
+type ServiceAccountPermissions struct {
+	Name      string              `json:"name"`
+	Namespace string              `json:"namespace"`
+	Bindings  []BindingInfo       `json:"bindings"`
+	Rules     []EffectiveRuleInfo `json:"rules"` // Deduplicated across bindings
+}
+
+// BindingInfo is a RoleBinding or ClusterRoleBinding that applies to the service account
+type BindingInfo struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	RoleKind  string `json:"roleKind"`
+	RoleName  string `json:"roleName"`
+	Subject   string `json:"subject"`           // The matching subject, e.g. the account or system:serviceaccounts
+	Missing   bool   `json:"missing,omitempty"` // The referenced role doesn't exist
+	Error     string `json:"error,omitempty"`   // The role couldn't be read
+}
+
+// EffectiveRuleInfo is one policy rule and where it applies
+type EffectiveRuleInfo struct {
+	Scope           string   `json:"scope"` // A namespace, or "cluster" for ClusterRoleBindings
+	Verbs           []string `json:"verbs"`
+	APIGroups       []string `json:"apiGroups,omitempty"`
+	Resources       []string `json:"resources,omitempty"`
+	ResourceNames   []string `json:"resourceNames,omitempty"`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+	Binding         string   `json:"binding"`
+}
+
+// ServiceAccountPermissions finds every binding that references the service
+// account (directly or through its groups) and summarizes the granted rules
+func (h *RBACHandler) ServiceAccountPermissions(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.CoreV1().ServiceAccounts(namespace).Get(context.Background(), name, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	result := ServiceAccountPermissions{
+		Name:      name,
+		Namespace: namespace,
+		Bindings:  []BindingInfo{},
+		Rules:     []EffectiveRuleInfo{},
+	}
+	seen := make(map[string]bool)
+
+	addRules := func(binding *BindingInfo, scope string, rules []rbacv1.PolicyRule, err error) {
+		if apierrors.IsNotFound(err) {
+			binding.Missing = true
+		} else if err != nil {
+			binding.Error = err.Error()
+		}
+		result.Bindings = append(result.Bindings, *binding)
+
+		for _, rule := range rules {
+			info := EffectiveRuleInfo{
+				Scope:           scope,
+				Verbs:           rule.Verbs,
+				APIGroups:       rule.APIGroups,
+				Resources:       rule.Resources,
+				ResourceNames:   rule.ResourceNames,
+				NonResourceURLs: rule.NonResourceURLs,
+				Binding:         fmt.Sprintf("%s/%s", binding.Kind, binding.Name),
+			}
+			key := fmt.Sprintf("%s|%v|%v|%v|%v|%v", scope, rule.Verbs, rule.APIGroups, rule.Resources, rule.ResourceNames, rule.NonResourceURLs)
+			if !seen[key] {
+				seen[key] = true
+				result.Rules = append(result.Rules, info)
+			}
+		}
+	}
+
+	clusterBindings, err := client.RbacV1().ClusterRoleBindings().List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	for _, crb := range clusterBindings.Items {
+		subject, ok := matchServiceAccountSubject(crb.Subjects, namespace, name)
+		if !ok {
+			continue
+		}
+		binding := BindingInfo{Kind: "ClusterRoleBinding", Name: crb.Name, RoleKind: crb.RoleRef.Kind, RoleName: crb.RoleRef.Name, Subject: subject}
+		role, err := client.RbacV1().ClusterRoles().Get(context.Background(), crb.RoleRef.Name, metav1.GetOptions{})
+		addRules(&binding, "cluster", roleRules(role, err), err)
+	}
+
+	// RoleBindings in any namespace can grant the account access there; fall
+	// back to its own namespace if listing cluster-wide is forbidden
+	bindings, err := client.RbacV1().RoleBindings("").List(context.Background(), listOptions(metav1.ListOptions{}))
+	if apierrors.IsForbidden(err) {
+		bindings, err = client.RbacV1().RoleBindings(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, rb := range bindings.Items {
+		subject, ok := matchServiceAccountSubject(rb.Subjects, namespace, name)
+		if !ok {
+			continue
+		}
+		binding := BindingInfo{Kind: "RoleBinding", Name: rb.Name, Namespace: rb.Namespace, RoleKind: rb.RoleRef.Kind, RoleName: rb.RoleRef.Name, Subject: subject}
+
+		// A RoleBinding to a ClusterRole grants its rules only within the binding's namespace
+		if rb.RoleRef.Kind == "ClusterRole" {
+			role, err := client.RbacV1().ClusterRoles().Get(context.Background(), rb.RoleRef.Name, metav1.GetOptions{})
+			addRules(&binding, rb.Namespace, roleRules(role, err), err)
+			continue
+		}
+
+		var rules []rbacv1.PolicyRule
+		role, err := client.RbacV1().Roles(rb.Namespace).Get(context.Background(), rb.RoleRef.Name, metav1.GetOptions{})
+		if err == nil {
+			rules = role.Rules
+		}
+		addRules(&binding, rb.Namespace, rules, err)
+	}
+
+	return result, nil
+}
+
+// roleRules returns a ClusterRole's rules, or nil if it couldn't be read
+func roleRules(role *rbacv1.ClusterRole, err error) []rbacv1.PolicyRule {
+	if err != nil {
+		return nil
+	}
+	return role.Rules
+}
+
+// matchServiceAccountSubject returns the subject that binds the service
+// account: the account itself or one of the groups every service account is in
+func matchServiceAccountSubject(subjects []rbacv1.Subject, namespace, name string) (string, bool) {
+	for _, s := range subjects {
+		switch s.Kind {
+		case rbacv1.ServiceAccountKind:
+			if s.Name == name && s.Namespace == namespace {
+				return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name), true
+			}
+		case rbacv1.UserKind:
+			if s.Name == fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name) {
+				return s.Name, true
+			}
+		case rbacv1.GroupKind:
+			switch s.Name {
+			case "system:serviceaccounts", "system:serviceaccounts:" + namespace, "system:authenticated":
+				return s.Name, true
+			}
+		}
+	}
+	return "", false
+}
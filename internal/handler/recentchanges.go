@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+const (
+	// defaultRecentWindow is used when ?since is not given
+	defaultRecentWindow = 10 * time.Minute
+	// maxRecentChanges caps the number of changed resources returned
+	maxRecentChanges = 500
+)
+
+// recentChangeTypes are the resource types scanned for recent changes
+var recentChangeTypes = []string{
+	"deployments", "statefulsets", "daemonsets", "jobs", "cronjobs", "pods",
+	"services", "ingresses", "networkpolicies", "configmaps", "secrets",
+	"pvcs", "hpas", "serviceaccounts",
+}
+
+// RecentChangesHandler approximates an activity feed from object metadata and events
+type RecentChangesHandler struct {
+	k8s    *service.K8sManager
+	filter *ResourceFilter
+}
+
+func NewRecentChangesHandler(k8s *service.K8sManager, filter *ResourceFilter) *RecentChangesHandler {
+	return &RecentChangesHandler{k8s: k8s, filter: filter}
+}
+
+type RecentChanges struct {
+	Since     string           `json:"since"`
+	Changes   []ResourceChange `json:"changes"`
+	Events    []EventInfo      `json:"events"`
+	Truncated bool             `json:"truncated,omitempty"`
+	Errors    []string         `json:"errors,omitempty"` // Types that could not be listed, e.g. forbidden
+}
+
+// ResourceChange is a resource created or modified within the window
+type ResourceChange struct {
+	Type      string `json:"type"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Action    string `json:"action"`            // created or updated
+	Manager   string `json:"manager,omitempty"` // Field manager of the latest write
+	Time      string `json:"time"`
+	Age       string `json:"age"`
+}
+
+// List returns resources changed and events seen since now minus ?since (default 10m).
+// Changes are best-effort: a resource counts as updated when a managedFields entry
+// other than a status write is newer than the cutoff.
+func (h *RecentChangesHandler) List(ctx *gofr.Context) (interface{}, error) {
+	window := defaultRecentWindow
+	if since := ctx.Param("since"); since != "" {
+		d, err := time.ParseDuration(since)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid since %q, expected a duration such as 10m or 2h", since)
+		}
+		window = d
+	}
+	cutoff := time.Now().Add(-window)
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	config, err := h.k8s.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	metaClient, err := metadata.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	result := RecentChanges{
+		Since:   cutoff.Format(time.RFC3339),
+		Changes: []ResourceChange{},
+		Events:  []EventInfo{},
+	}
+
+	// Metadata-only lists carry managedFields without specs or statuses
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		changes []recentChange
+	)
+	for _, resourceType := range recentChangeTypes {
+		if !h.filter.Enabled(resourceType) {
+			continue
+		}
+		gvr, err := gvrFor(resourceType)
+		if err != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(resourceType string, gvr schema.GroupVersionResource) {
+			defer wg.Done()
+			list, err := metaClient.Resource(gvr).Namespace(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", resourceType, err))
+				return
+			}
+			for _, item := range list.Items {
+				if change, ok := recentChangeFor(resourceType, &item.ObjectMeta, cutoff); ok {
+					changes = append(changes, change)
+				}
+			}
+		}(resourceType, gvr)
+	}
+	wg.Wait()
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].time.After(changes[j].time)
+	})
+	if len(changes) > maxRecentChanges {
+		changes = changes[:maxRecentChanges]
+		result.Truncated = true
+	}
+	for _, change := range changes {
+		result.Changes = append(result.Changes, change.ResourceChange)
+	}
+	sort.Strings(result.Errors)
+
+	if h.filter.Enabled("events") {
+		client, err := h.k8s.GetClient()
+		if err != nil {
+			return nil, err
+		}
+		events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("events: %v", err))
+			return result, nil
+		}
+
+		var recent []corev1.Event
+		for _, event := range events.Items {
+			if eventTime(&event).After(cutoff) {
+				recent = append(recent, event)
+			}
+		}
+		sort.Slice(recent, func(i, j int) bool {
+			return eventTime(&recent[i]).After(eventTime(&recent[j]))
+		})
+		for _, event := range recent {
+			result.Events = append(result.Events, eventToInfo(&event))
+		}
+	}
+
+	return result, nil
+}
+
+// recentChange keeps the parsed time alongside the response for sorting
+type recentChange struct {
+	ResourceChange
+	time time.Time
+}
+
+// recentChangeFor reports whether an object was created or written after cutoff.
+// Status subresource writes are ignored since controllers make them constantly.
+func recentChangeFor(resourceType string, meta *metav1.ObjectMeta, cutoff time.Time) (recentChange, bool) {
+	change := recentChange{
+		ResourceChange: ResourceChange{
+			Type:      resourceType,
+			Kind:      resourceMetaMap[resourceType].kind,
+			Name:      meta.Name,
+			Namespace: meta.Namespace,
+			Action:    "created",
+		},
+		time: meta.CreationTimestamp.Time,
+	}
+
+	// Writes in the same second as creation are part of creating it
+	var latest *metav1.ManagedFieldsEntry
+	for i, entry := range meta.ManagedFields {
+		if entry.Time == nil || entry.Subresource == "status" {
+			continue
+		}
+		if latest == nil || entry.Time.After(latest.Time.Time) {
+			latest = &meta.ManagedFields[i]
+		}
+	}
+	if latest != nil {
+		change.Manager = latest.Manager
+		if latest.Time.After(change.time) {
+			change.time = latest.Time.Time
+			change.Action = "updated"
+		}
+	}
+
+	if !change.time.After(cutoff) {
+		return recentChange{}, false
+	}
+	change.Time = change.time.Format(time.RFC3339)
+	change.Age = formatAge(change.time)
+	return change, true
+}
+
+// eventTime is when an event was last seen, whichever timestamp field the source filled in
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
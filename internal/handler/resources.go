@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// ResourceHandler provides generic operations that work on any resource type in resourceMetaMap
+type ResourceHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewResourceHandler(k8s *service.K8sManager) *ResourceHandler {
+	return &ResourceHandler{k8s: k8s}
+}
+
+// gvrFor returns the GroupVersionResource for a resource type in resourceMetaMap
+func gvrFor(resourceType string) (schema.GroupVersionResource, error) {
+	meta, ok := resourceMetaMap[resourceType]
+	if !ok {
+		return schema.GroupVersionResource{}, errInvalidResourceType
+	}
+
+	gv, err := schema.ParseGroupVersion(meta.apiVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return gv.WithResource(meta.resource), nil
+}
+
+// formatDeletionTimestamp returns the deletion timestamp as RFC3339, or "" if not being deleted
+func formatDeletionTimestamp(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// resourceClient returns a dynamic client for the resource, scoped to namespace if set
+func resourceClient(k8s *service.K8sManager, gvr schema.GroupVersionResource, namespace string) (dynamic.ResourceInterface, error) {
+	config, err := k8s.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dynClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if namespace != "" {
+		return dynClient.Resource(gvr).Namespace(namespace), nil
+	}
+	return dynClient.Resource(gvr), nil
+}
+
+// patchRequest is the body for the patch endpoints. Patch is a JSON Patch
+// (RFC 6902) array for patchType "json", or a partial object for "merge" and
+// "strategic".
+type patchRequest struct {
+	PatchType string          `json:"patchType"`
+	Patch     json.RawMessage `json:"patch"`
+}
+
+var patchTypes = map[string]types.PatchType{
+	"json":      types.JSONPatchType,
+	"merge":     types.MergePatchType,
+	"strategic": types.StrategicMergePatchType,
+}
+
+// Patch applies a JSON, merge, or strategic merge patch to a namespaced resource
+func (h *ResourceHandler) Patch(ctx *gofr.Context) (interface{}, error) {
+	return h.patch(ctx, ctx.PathParam("type"), ctx.PathParam("namespace"), ctx.PathParam("name"))
+}
+
+// PatchClusterScoped applies a JSON, merge, or strategic merge patch to a cluster-scoped resource
+func (h *ResourceHandler) PatchClusterScoped(ctx *gofr.Context) (interface{}, error) {
+	return h.patch(ctx, ctx.PathParam("type"), "", ctx.PathParam("name"))
+}
+
+func (h *ResourceHandler) patch(ctx *gofr.Context, resourceType, namespace, name string) (interface{}, error) {
+	var req patchRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+
+	if req.PatchType == "" {
+		req.PatchType = "json"
+	}
+	patchType, ok := patchTypes[req.PatchType]
+	if !ok {
+		return nil, fmt.Errorf("invalid patchType %q: must be json, merge, or strategic", req.PatchType)
+	}
+	if len(req.Patch) == 0 {
+		return nil, fmt.Errorf("patch is required")
+	}
+
+	gvr, err := gvrFor(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := resourceClient(h.k8s, gvr, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := resource.Patch(ctx, name, patchType, req.Patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"status":          "patched",
+		"resourceVersion": patched.GetResourceVersion(),
+	}, nil
+}
+
+// RemoveFinalizers clears metadata.finalizers on a namespaced resource
+func (h *ResourceHandler) RemoveFinalizers(ctx *gofr.Context) (interface{}, error) {
+	return h.removeFinalizers(ctx, ctx.PathParam("type"), ctx.PathParam("namespace"), ctx.PathParam("name"))
+}
+
+// RemoveFinalizersClusterScoped clears metadata.finalizers on a cluster-scoped resource
+func (h *ResourceHandler) RemoveFinalizersClusterScoped(ctx *gofr.Context) (interface{}, error) {
+	return h.removeFinalizers(ctx, ctx.PathParam("type"), "", ctx.PathParam("name"))
+}
+
+// removeFinalizers is the escape hatch for resources stuck in Terminating.
+// Skipping finalizers can leak the external resources they were guarding, so
+// the caller must pass confirm=true.
+func (h *ResourceHandler) removeFinalizers(ctx *gofr.Context, resourceType, namespace, name string) (interface{}, error) {
+	if ctx.Param("confirm") != "true" {
+		return nil, badRequestError{"removing finalizers skips cleanup that controllers rely on and may orphan external resources; pass confirm=true to proceed"}
+	}
+
+	gvr, err := gvrFor(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := resourceClient(h.k8s, gvr, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := []byte(`{"metadata":{"finalizers":null}}`)
+	if _, err := resource.Patch(context.Background(), name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, err
+	}
+
+	// Namespaces also block on spec.finalizers, which can only be cleared through the finalize subresource
+	if resourceType == "namespaces" {
+		if err := h.finalizeNamespace(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]string{
+		"message": fmt.Sprintf("Finalizers removed from %s %s", resourceMetaMap[resourceType].kind, name),
+		"warning": "Finalizers were bypassed; check for leftover external resources",
+	}, nil
+}
+
+// finalizeNamespace clears spec.finalizers on a namespace
+func (h *ResourceHandler) finalizeNamespace(name string) error {
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return err
+	}
+
+	ns, err := client.CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	ns.Spec.Finalizers = nil
+	_, err = client.CoreV1().Namespaces().Finalize(context.Background(), ns, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ResponseSizeGuard rejects API responses larger than a configured size with a
+// 413, so an accidental all-namespace query can't send the browser hundreds of
+// megabytes. Streaming endpoints are served by earlier middlewares and never
+// reach it.
+type ResponseSizeGuard struct {
+	maxBytes int64
+}
+
+// NewResponseSizeGuard creates a guard for responses over maxBytes. Zero disables it.
+func NewResponseSizeGuard(maxBytes int64) *ResponseSizeGuard {
+	return &ResponseSizeGuard{maxBytes: maxBytes}
+}
+
+// Middleware buffers /api/ responses up to the limit before sending them
+func (g *ResponseSizeGuard) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if g.maxBytes <= 0 || !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buffered := &limitedResponseWriter{ResponseWriter: w, maxBytes: g.maxBytes, status: http.StatusOK}
+		next.ServeHTTP(buffered, r)
+
+		if buffered.exceeded {
+			w.Header().Del("Content-Length")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]string{
+					"message": fmt.Sprintf("response exceeds the %d MB limit; select a namespace or narrow the query and try again",
+						g.maxBytes>>20),
+				},
+			})
+			return
+		}
+
+		w.WriteHeader(buffered.status)
+		w.Write(buffered.body.Bytes())
+	})
+}
+
+// limitedResponseWriter buffers a response, dropping it once it grows past maxBytes
+type limitedResponseWriter struct {
+	http.ResponseWriter
+	maxBytes int64
+	status   int
+	body     bytes.Buffer
+	exceeded bool
+}
+
+func (w *limitedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *limitedResponseWriter) Write(p []byte) (int, error) {
+	if w.exceeded {
+		return len(p), nil
+	}
+	if int64(w.body.Len()+len(p)) > w.maxBytes {
+		w.exceeded = true
+		w.body = bytes.Buffer{}
+		return len(p), nil
+	}
+	return w.body.Write(p)
+}
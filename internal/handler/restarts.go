@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// restartEventReasons are the container events that mark a restart or its cause
+var restartEventReasons = map[string]bool{
+	"Started":   true,
+	"Killing":   true,
+	"Unhealthy": true,
+	"BackOff":   true,
+}
+
+// PodRestarts is a rough restart timeline for each container in a pod
+type PodRestarts struct {
+	Name       string              `json:"name"`
+	Namespace  string              `json:"namespace"`
+	StartTime  string              `json:"startTime,omitempty"`
+	Containers []ContainerRestarts `json:"containers"`
+}
+
+type ContainerRestarts struct {
+	Name            string              `json:"name"`
+	Init            bool                `json:"init,omitempty"`
+	RestartCount    int32               `json:"restartCount"`
+	State           string              `json:"state"`
+	RunningSince    string              `json:"runningSince,omitempty"`    // Start of the current instance
+	AverageInterval string              `json:"averageInterval,omitempty"` // Pod uptime divided by restarts
+	LastTermination *TerminationDetails `json:"lastTermination,omitempty"`
+	Events          []RestartEvent      `json:"events,omitempty"`
+}
+
+// TerminationDetails is how the previous instance of a container ended
+type TerminationDetails struct {
+	Reason     string `json:"reason"`
+	Message    string `json:"message,omitempty"`
+	ExitCode   int32  `json:"exitCode"`
+	Signal     int32  `json:"signal,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt"`
+	RanFor     string `json:"ranFor,omitempty"` // How long the instance ran before terminating
+	Ago        string `json:"ago"`
+}
+
+// RestartEvent is an aggregated container event; Count and the first and last
+// seen times show how often it repeated
+type RestartEvent struct {
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	Count     int32  `json:"count"`
+	FirstSeen string `json:"firstSeen,omitempty"`
+	LastSeen  string `json:"lastSeen"`
+
+	lastSeen time.Time
+}
+
+// Restarts returns each container's restart count with the timing of its
+// current instance and last termination, plus the restart-related events still
+// retained (by default the API server keeps events for an hour)
+func (h *PodHandler) Restarts(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return lookupError(err)
+	}
+
+	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=Pod", name, namespace)
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	// Container events carry a field path like spec.containers{app}
+	eventsByContainer := make(map[string][]RestartEvent)
+	for i := range events.Items {
+		event := &events.Items[i]
+		if !restartEventReasons[event.Reason] {
+			continue
+		}
+		path := event.InvolvedObject.FieldPath
+		start, end := strings.Index(path, "{"), strings.LastIndex(path, "}")
+		if start < 0 || end <= start {
+			continue
+		}
+		container := path[start+1 : end]
+
+		last := eventTime(event)
+		entry := RestartEvent{
+			Reason:   event.Reason,
+			Message:  event.Message,
+			Count:    event.Count,
+			LastSeen: last.Format(time.RFC3339),
+			lastSeen: last,
+		}
+		if entry.Count == 0 {
+			entry.Count = 1
+			if event.Series != nil {
+				entry.Count = event.Series.Count
+			}
+		}
+		if !event.FirstTimestamp.IsZero() {
+			entry.FirstSeen = event.FirstTimestamp.Format(time.RFC3339)
+		}
+		eventsByContainer[container] = append(eventsByContainer[container], entry)
+	}
+
+	result := PodRestarts{
+		Name:       pod.Name,
+		Namespace:  pod.Namespace,
+		Containers: []ContainerRestarts{},
+	}
+	if pod.Status.StartTime != nil {
+		result.StartTime = pod.Status.StartTime.Format(time.RFC3339)
+	}
+
+	add := func(cs corev1.ContainerStatus, init bool) {
+		restarts := ContainerRestarts{
+			Name:         cs.Name,
+			Init:         init,
+			RestartCount: cs.RestartCount,
+			State:        containerStatusSummary(cs).State,
+			Events:       eventsByContainer[cs.Name],
+		}
+		if cs.State.Running != nil {
+			restarts.RunningSince = cs.State.Running.StartedAt.Format(time.RFC3339)
+		}
+		if cs.RestartCount > 0 && pod.Status.StartTime != nil {
+			interval := time.Since(pod.Status.StartTime.Time) / time.Duration(cs.RestartCount)
+			restarts.AverageInterval = interval.Round(time.Second).String()
+		}
+		if last := cs.LastTerminationState.Terminated; last != nil {
+			restarts.LastTermination = terminationDetails(last)
+		}
+		sort.Slice(restarts.Events, func(i, j int) bool {
+			return restarts.Events[i].lastSeen.After(restarts.Events[j].lastSeen)
+		})
+		result.Containers = append(result.Containers, restarts)
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		add(cs, true)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		add(cs, false)
+	}
+
+	return result, nil
+}
+
+func terminationDetails(t *corev1.ContainerStateTerminated) *TerminationDetails {
+	details := &TerminationDetails{
+		Reason:     t.Reason,
+		Message:    t.Message,
+		ExitCode:   t.ExitCode,
+		Signal:     t.Signal,
+		FinishedAt: t.FinishedAt.Format(time.RFC3339),
+		Ago:        formatAge(t.FinishedAt.Time),
+	}
+	if !t.StartedAt.IsZero() {
+		details.StartedAt = t.StartedAt.Format(time.RFC3339)
+		details.RanFor = t.FinishedAt.Sub(t.StartedAt.Time).Round(time.Second).String()
+	}
+	return details
+}
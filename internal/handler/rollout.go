@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// revisionAnnotation is set by the deployment controller on deployments and their ReplicaSets
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// RolloutStatus is a deployment's rollout progress, in the terms kubectl rollout status uses
+type RolloutStatus struct {
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace"`
+	Revision      string `json:"revision,omitempty"`
+	NewReplicaSet string `json:"newReplicaSet,omitempty"`
+	Desired       int32  `json:"desired"`
+	Updated       int32  `json:"updated"`
+	Ready         int32  `json:"ready"`
+	Available     int32  `json:"available"`
+	OldReplicas   int32  `json:"oldReplicas"` // Pods still owned by previous ReplicaSets
+	Phase         string `json:"phase"`       // progressing, complete, failed or paused
+	Message       string `json:"message"`
+}
+
+// Done reports whether the rollout has reached a final state
+func (s RolloutStatus) Done() bool {
+	return s.Phase == "complete" || s.Phase == "failed"
+}
+
+// RolloutStatus returns a snapshot of the deployment's rollout progress
+func (h *DeploymentHandler) RolloutStatus(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return lookupError(err)
+	}
+
+	return rolloutStatus(ctx, client, deployment)
+}
+
+// rolloutStatus computes progress from the deployment status and its ReplicaSets
+func rolloutStatus(ctx context.Context, client kubernetes.Interface, d *appsv1.Deployment) (RolloutStatus, error) {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+
+	status := RolloutStatus{
+		Name:      d.Name,
+		Namespace: d.Namespace,
+		Revision:  d.Annotations[revisionAnnotation],
+		Desired:   desired,
+		Updated:   d.Status.UpdatedReplicas,
+		Ready:     d.Status.ReadyReplicas,
+		Available: d.Status.AvailableReplicas,
+	}
+
+	if d.Spec.Selector != nil {
+		replicaSets, err := client.AppsV1().ReplicaSets(d.Namespace).List(ctx, listOptions(metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(d.Spec.Selector),
+		}))
+		if err != nil {
+			return status, err
+		}
+		for _, rs := range replicaSets.Items {
+			if owner := metav1.GetControllerOf(&rs); owner == nil || owner.UID != d.UID {
+				continue
+			}
+			if status.Revision != "" && rs.Annotations[revisionAnnotation] == status.Revision {
+				status.NewReplicaSet = rs.Name
+				continue
+			}
+			status.OldReplicas += rs.Status.Replicas
+		}
+	}
+
+	// Same checks, in the same order, as kubectl rollout status, with paused reported first
+	switch {
+	case d.Spec.Paused:
+		status.Phase = "paused"
+		status.Message = "Rollout is paused"
+	case d.Status.ObservedGeneration < d.Generation:
+		status.Phase = "progressing"
+		status.Message = "Waiting for the deployment spec update to be observed"
+	case progressDeadlineExceeded(d):
+		status.Phase = "failed"
+		status.Message = "Rollout exceeded its progress deadline"
+	case d.Status.UpdatedReplicas < desired:
+		status.Phase = "progressing"
+		status.Message = fmt.Sprintf("%d of %d new replicas have been updated", d.Status.UpdatedReplicas, desired)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		status.Phase = "progressing"
+		status.Message = fmt.Sprintf("%d old replicas are pending termination", d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		status.Phase = "progressing"
+		status.Message = fmt.Sprintf("%d of %d updated replicas are available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	default:
+		status.Phase = "complete"
+		status.Message = "Rollout complete"
+	}
+
+	return status, nil
+}
+
+func progressDeadlineExceeded(d *appsv1.Deployment) bool {
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse && c.Reason == "ProgressDeadlineExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
+// RolloutWatchHandler streams a deployment's rollout progress over SSE
+type RolloutWatchHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewRolloutWatchHandler(k8s *service.K8sManager) *RolloutWatchHandler {
+	return &RolloutWatchHandler{k8s: k8s}
+}
+
+// Middleware serves GET /api/deployments/{namespace}/{name}/rollout/watch as an SSE stream
+func (h *RolloutWatchHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/deployments/") {
+			parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/deployments/"), "/")
+			if len(parts) == 4 && parts[2] == "rollout" && parts[3] == "watch" {
+				h.HandleWatch(w, r, parts[0], parts[1])
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleWatch sends the current rollout status, then an "update" whenever the
+// deployment or one of its ReplicaSets changes it. The stream ends with a
+// "complete" or "failed" message, or "deleted" if the deployment goes away.
+func (h *RolloutWatchHandler) HandleWatch(w http.ResponseWriter, r *http.Request, namespace, name string) {
+	client, err := h.k8s.GetStreamingClient()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ctx := r.Context()
+
+	// Check the deployment exists before switching to SSE so a typo gets a plain 404
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		http.Error(w, fmt.Sprintf("deployment %s/%s not found", namespace, name), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	var last RolloutStatus
+	// send reports the status if it changed and returns true once the rollout is done
+	send := func(d *appsv1.Deployment) bool {
+		status, err := rolloutStatus(ctx, client, d)
+		if err != nil {
+			sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "deployments", Namespace: namespace, Data: err.Error()})
+			return true
+		}
+		if reflect.DeepEqual(status, last) {
+			return false
+		}
+		last = status
+
+		messageType := "update"
+		if status.Done() {
+			messageType = status.Phase
+		}
+		sendSSEMessage(w, flusher, SSEMessage{Type: messageType, Resource: "deployments", Namespace: namespace, Data: status})
+		return status.Done()
+	}
+
+	if send(deployment) {
+		return
+	}
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		deploymentWatch, err := client.AppsV1().Deployments(namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+			ResourceVersion: deployment.ResourceVersion,
+		})
+		if err != nil {
+			sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "deployments", Namespace: namespace, Data: err.Error()})
+			return
+		}
+		// ReplicaSet events only trigger a recompute; the status is read from the latest deployment.
+		// Starting from a list's resourceVersion skips the initial ADDED events.
+		selector := metav1.FormatLabelSelector(deployment.Spec.Selector)
+		replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, listOptions(metav1.ListOptions{LabelSelector: selector, Limit: 1}))
+		if err != nil {
+			deploymentWatch.Stop()
+			sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "deployments", Namespace: namespace, Data: err.Error()})
+			return
+		}
+		replicaSetWatch, err := client.AppsV1().ReplicaSets(namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector:   selector,
+			ResourceVersion: replicaSets.ResourceVersion,
+		})
+		if err != nil {
+			deploymentWatch.Stop()
+			sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "deployments", Namespace: namespace, Data: err.Error()})
+			return
+		}
+
+		done := false
+	consume:
+		for {
+			select {
+			case <-ctx.Done():
+				done = true
+				break consume
+			case <-keepAlive.C:
+				fmt.Fprint(w, ": keep-alive\n\n")
+				flusher.Flush()
+			case ev, ok := <-deploymentWatch.ResultChan():
+				if !ok || ev.Type == watch.Error {
+					// Typically 410 Gone: restart both watches from a fresh read
+					break consume
+				}
+				if ev.Type == watch.Deleted {
+					sendSSEMessage(w, flusher, SSEMessage{Type: "deleted", Resource: "deployments", Namespace: namespace, Data: name})
+					done = true
+					break consume
+				}
+				if d, ok := ev.Object.(*appsv1.Deployment); ok {
+					deployment = d
+					if send(deployment) {
+						done = true
+						break consume
+					}
+				}
+			case ev, ok := <-replicaSetWatch.ResultChan():
+				if !ok || ev.Type == watch.Error {
+					break consume
+				}
+				if send(deployment) {
+					done = true
+					break consume
+				}
+			}
+		}
+		deploymentWatch.Stop()
+		replicaSetWatch.Stop()
+
+		if done || ctx.Err() != nil {
+			return
+		}
+
+		deployment, err = client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			sendSSEMessage(w, flusher, SSEMessage{Type: "deleted", Resource: "deployments", Namespace: namespace, Data: name})
+			return
+		}
+		if err != nil {
+			sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "deployments", Namespace: namespace, Data: err.Error()})
+			return
+		}
+		// Changes may have been missed while the watches were down
+		if send(deployment) {
+			return
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// envExecTimeout bounds the `env` exec used to read a running container's environment
+const envExecTimeout = 15 * time.Second
+
+// RuntimeEnv compares a container's declared environment with what a running pod actually has
+type RuntimeEnv struct {
+	Name      string               `json:"name"`
+	Namespace string               `json:"namespace"`
+	Pod       string               `json:"pod"`
+	Container string               `json:"container"`
+	PodStart  string               `json:"podStart,omitempty"`
+	Drifted   bool                 `json:"drifted"`
+	Variables []RuntimeEnvVariable `json:"variables"`
+}
+
+// RuntimeEnvVariable is one variable side by side. Status is "match", "differs",
+// "missing" (declared but not set in the pod), "extra" (set by the image or
+// kubelet, e.g. PATH or service links) or "dynamic" (a field or resource
+// reference, or a $(VAR) expansion, whose value is only known at runtime).
+type RuntimeEnvVariable struct {
+	Name      string `json:"name"`
+	Declared  string `json:"declared,omitempty"`
+	Actual    string `json:"actual,omitempty"`
+	ValueFrom string `json:"valueFrom,omitempty"`
+	Status    string `json:"status"`
+}
+
+// RuntimeEnv execs `env` in a running pod of the deployment and compares the
+// result with the declared env. ?pod defaults to the oldest running pod, the one
+// most likely to predate a spec change; ?container defaults to the first.
+func (h *DeploymentHandler) RuntimeEnv(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return lookupError(err)
+	}
+
+	pod, err := h.runtimeEnvPod(ctx, deployment.Spec.Selector, namespace, ctx.Param("pod"))
+	if err != nil {
+		return nil, err
+	}
+
+	container := ctx.Param("container")
+	if container == "" && len(deployment.Spec.Template.Spec.Containers) > 0 {
+		container = deployment.Spec.Template.Spec.Containers[0].Name
+	}
+
+	var declared []EnvVar
+	info := deploymentToInfoWithRunningContainers(deployment, nil, client, namespace, h.filter)
+	found := false
+	for _, c := range info.ContainerDetails {
+		if c.Name == container {
+			declared = c.Env
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("container %q not found in deployment %s", container, name)
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, envExecTimeout)
+	defer cancel()
+
+	var recording *execRecording
+	if h.recorder != nil {
+		recording, err = h.recorder.start(namespace, pod.Name, container, "env")
+		if err != nil {
+			return nil, fmt.Errorf("failed to start recording: %w", err)
+		}
+		defer recording.finish()
+		recording.record("input", []byte("env\n"))
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = execInPod(execCtx, h.k8s, namespace, pod.Name, container, []string{"env"}, nil, &stdout, &stderr)
+	recording.record("output", append(stdout.Bytes(), stderr.Bytes()...))
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to read environment of %s: %s", pod.Name, msg)
+		}
+		return nil, fmt.Errorf("failed to read environment of %s: %w", pod.Name, err)
+	}
+
+	result := RuntimeEnv{
+		Name:      deployment.Name,
+		Namespace: namespace,
+		Pod:       pod.Name,
+		Container: container,
+		Variables: compareEnv(declared, parseEnvOutput(stdout.String())),
+	}
+	if pod.Status.StartTime != nil {
+		result.PodStart = pod.Status.StartTime.Format(time.RFC3339)
+	}
+	for _, v := range result.Variables {
+		if v.Status == "differs" || v.Status == "missing" {
+			result.Drifted = true
+		}
+	}
+
+	return result, nil
+}
+
+// runtimeEnvPod returns the named pod if it matches the selector, or the oldest running pod that does
+func (h *DeploymentHandler) runtimeEnvPod(ctx context.Context, selector *metav1.LabelSelector, namespace, podName string) (*corev1.Pod, error) {
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if podName != "" {
+		pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		// Only the deployment's own pods may be exec'd into through this endpoint
+		if !labelSelector.Matches(labels.Set(pod.Labels)) {
+			return nil, badRequestError{fmt.Sprintf("pod %s does not belong to the deployment", podName)}
+		}
+		return pod, nil
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, listOptions(metav1.ListOptions{
+		LabelSelector: labelSelector.String(),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	var oldest *corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil || pod.Status.StartTime == nil {
+			continue
+		}
+		if oldest == nil || pod.Status.StartTime.Before(oldest.Status.StartTime) {
+			oldest = pod
+		}
+	}
+	if oldest == nil {
+		return nil, errors.New("no running pods found")
+	}
+	return oldest, nil
+}
+
+// parseEnvOutput parses `env` output. Lines without "=" continue a multi-line value.
+func parseEnvOutput(output string) map[string]string {
+	env := make(map[string]string)
+	last := ""
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		if name, value, ok := strings.Cut(line, "="); ok && name != "" && !strings.ContainsAny(name, " \t") {
+			env[name] = value
+			last = name
+			continue
+		}
+		if last != "" {
+			env[last] += "\n" + line
+		}
+	}
+	return env
+}
+
+// compareEnv pairs declared variables with the actual environment. Declared
+// variables come first in spec order, then extras sorted by name.
+func compareEnv(declared []EnvVar, actual map[string]string) []RuntimeEnvVariable {
+	result := []RuntimeEnvVariable{}
+	seen := make(map[string]int) // name -> index in result
+
+	for _, d := range declared {
+		// envFrom sources that couldn't be expanded are listed as "prefix* (all keys)"
+		if strings.HasSuffix(d.Name, "* (all keys)") {
+			continue
+		}
+
+		v := RuntimeEnvVariable{Name: d.Name, Declared: d.Value, ValueFrom: d.ValueFrom}
+		value, ok := actual[d.Name]
+		v.Actual = value
+		switch {
+		case !ok:
+			v.Status = "missing"
+		case strings.HasPrefix(d.ValueFrom, "field:") || strings.HasPrefix(d.ValueFrom, "resource:") || strings.Contains(d.Value, "$("):
+			v.Status = "dynamic"
+		case value == d.Value:
+			v.Status = "match"
+		default:
+			v.Status = "differs"
+		}
+
+		// A later declaration of the same name (env after envFrom) wins
+		if i, ok := seen[d.Name]; ok {
+			result[i] = v
+			continue
+		}
+		seen[d.Name] = len(result)
+		result = append(result, v)
+	}
+
+	var extras []string
+	for name := range actual {
+		if _, ok := seen[name]; !ok {
+			extras = append(extras, name)
+		}
+	}
+	sort.Strings(extras)
+	for _, name := range extras {
+		result = append(result, RuntimeEnvVariable{Name: name, Actual: actual[name], Status: "extra"})
+	}
+
+	return result
+}
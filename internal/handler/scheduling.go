@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+
+	"gofr.dev/pkg/gofr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+type SchedulingHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewSchedulingHandler(k8s *service.K8sManager) *SchedulingHandler {
+	return &SchedulingHandler{k8s: k8s}
+}
+
+type RuntimeClassInfo struct {
+	Name         string            `json:"name"`
+	Handler      string            `json:"handler"`
+	Overhead     map[string]string `json:"overhead,omitempty"`
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	Age          string            `json:"age"`
+}
+
+type PriorityClassInfo struct {
+	Name             string `json:"name"`
+	Value            int32  `json:"value"`
+	GlobalDefault    bool   `json:"globalDefault"`
+	PreemptionPolicy string `json:"preemptionPolicy"`
+	Description      string `json:"description,omitempty"`
+	Age              string `json:"age"`
+}
+
+// ListPriorityClasses returns all PriorityClasses in the cluster
+func (h *SchedulingHandler) ListPriorityClasses(ctx *gofr.Context) (interface{}, error) {
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pcs, err := client.SchedulingV1().PriorityClasses().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []PriorityClassInfo
+	for _, pc := range pcs.Items {
+		preemptionPolicy := "PreemptLowerPriority"
+		if pc.PreemptionPolicy != nil {
+			preemptionPolicy = string(*pc.PreemptionPolicy)
+		}
+
+		result = append(result, PriorityClassInfo{
+			Name:             pc.Name,
+			Value:            pc.Value,
+			GlobalDefault:    pc.GlobalDefault,
+			PreemptionPolicy: preemptionPolicy,
+			Description:      pc.Description,
+			Age:              formatAge(pc.CreationTimestamp.Time),
+		})
+	}
+
+	return result, nil
+}
+
+// ListRuntimeClasses returns all RuntimeClasses in the cluster
+func (h *SchedulingHandler) ListRuntimeClasses(ctx *gofr.Context) (interface{}, error) {
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	rcs, err := client.NodeV1().RuntimeClasses().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []RuntimeClassInfo
+	for _, rc := range rcs.Items {
+		info := RuntimeClassInfo{
+			Name:    rc.Name,
+			Handler: rc.Handler,
+			Age:     formatAge(rc.CreationTimestamp.Time),
+		}
+
+		if rc.Overhead != nil {
+			info.Overhead = make(map[string]string)
+			for name, q := range rc.Overhead.PodFixed {
+				info.Overhead[string(name)] = q.String()
+			}
+		}
+		if rc.Scheduling != nil {
+			info.NodeSelector = rc.Scheduling.NodeSelector
+		}
+
+		result = append(result, info)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// allNamespacesParam is the namespace param value that explicitly requests all namespaces
+const allNamespacesParam = "*"
+
+// NamespaceScope is the namespace a request resolved to and why
+type NamespaceScope struct {
+	Namespace     string `json:"namespace"` // "" when AllNamespaces
+	AllNamespaces bool   `json:"allNamespaces"`
+	Source        string `json:"source"` // "param", "session", "context", or "all"
+}
+
+// resolveNamespace picks the namespace for a list request: an explicit namespace
+// param, then the session-selected namespace, then the context default. All
+// namespaces are only used when requested with namespace=*.
+func resolveNamespace(ctx *gofr.Context, k8s *service.K8sManager) NamespaceScope {
+	switch namespace := ctx.Param("namespace"); namespace {
+	case allNamespacesParam:
+		return NamespaceScope{AllNamespaces: true, Source: "all"}
+	case "":
+	default:
+		return NamespaceScope{Namespace: namespace, Source: "param"}
+	}
+
+	if namespace := k8s.SelectedNamespace(); namespace != "" {
+		return NamespaceScope{Namespace: namespace, Source: "session"}
+	}
+	return NamespaceScope{Namespace: k8s.GetDefaultNamespace(), Source: "context"}
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type SearchHandler struct {
-	k8s *service.K8sManager
+	k8s    *service.K8sManager
+	filter *ResourceFilter
 }
 
-func NewSearchHandler(k8s *service.K8sManager) *SearchHandler {
-	return &SearchHandler{k8s: k8s}
+func NewSearchHandler(k8s *service.K8sManager, filter *ResourceFilter) *SearchHandler {
+	return &SearchHandler{k8s: k8s, filter: filter}
 }
 
 type SearchResult struct {
@@ -29,7 +30,7 @@ type SearchResult struct {
 // Search searches across multiple resource types
 func (h *SearchHandler) Search(ctx *gofr.Context) (interface{}, error) {
 	query := strings.ToLower(ctx.Param("q"))
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	if query == "" {
 		return []SearchResult{}, nil
@@ -43,146 +44,162 @@ func (h *SearchHandler) Search(ctx *gofr.Context) (interface{}, error) {
 	var results []SearchResult
 
 	// Search Pods
-	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
-	if err == nil {
-		for _, pod := range pods.Items {
-			if strings.Contains(strings.ToLower(pod.Name), query) {
-				results = append(results, SearchResult{
-					Type:      "Pod",
-					Name:      pod.Name,
-					Namespace: pod.Namespace,
-					Status:    string(pod.Status.Phase),
-					Age:       formatAge(pod.CreationTimestamp.Time),
-				})
-			}
-			if len(results) >= 50 {
-				break
+	if h.filter.Enabled("pods") {
+		pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err == nil {
+			for _, pod := range pods.Items {
+				if strings.Contains(strings.ToLower(pod.Name), query) {
+					results = append(results, SearchResult{
+						Type:      "Pod",
+						Name:      pod.Name,
+						Namespace: pod.Namespace,
+						Status:    string(pod.Status.Phase),
+						Age:       formatAge(pod.CreationTimestamp.Time),
+					})
+				}
+				if len(results) >= 50 {
+					break
+				}
 			}
 		}
 	}
 
 	// Search Deployments
-	deployments, err := client.AppsV1().Deployments(namespace).List(context.Background(), metav1.ListOptions{})
-	if err == nil {
-		for _, dep := range deployments.Items {
-			if strings.Contains(strings.ToLower(dep.Name), query) {
-				results = append(results, SearchResult{
-					Type:      "Deployment",
-					Name:      dep.Name,
-					Namespace: dep.Namespace,
-					Age:       formatAge(dep.CreationTimestamp.Time),
-				})
-			}
-			if len(results) >= 50 {
-				break
+	if h.filter.Enabled("deployments") {
+		deployments, err := client.AppsV1().Deployments(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err == nil {
+			for _, dep := range deployments.Items {
+				if strings.Contains(strings.ToLower(dep.Name), query) {
+					results = append(results, SearchResult{
+						Type:      "Deployment",
+						Name:      dep.Name,
+						Namespace: dep.Namespace,
+						Age:       formatAge(dep.CreationTimestamp.Time),
+					})
+				}
+				if len(results) >= 50 {
+					break
+				}
 			}
 		}
 	}
 
 	// Search Services
-	services, err := client.CoreV1().Services(namespace).List(context.Background(), metav1.ListOptions{})
-	if err == nil {
-		for _, svc := range services.Items {
-			if strings.Contains(strings.ToLower(svc.Name), query) {
-				results = append(results, SearchResult{
-					Type:      "Service",
-					Name:      svc.Name,
-					Namespace: svc.Namespace,
-					Age:       formatAge(svc.CreationTimestamp.Time),
-				})
-			}
-			if len(results) >= 50 {
-				break
+	if h.filter.Enabled("services") {
+		services, err := client.CoreV1().Services(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err == nil {
+			for _, svc := range services.Items {
+				if strings.Contains(strings.ToLower(svc.Name), query) {
+					results = append(results, SearchResult{
+						Type:      "Service",
+						Name:      svc.Name,
+						Namespace: svc.Namespace,
+						Age:       formatAge(svc.CreationTimestamp.Time),
+					})
+				}
+				if len(results) >= 50 {
+					break
+				}
 			}
 		}
 	}
 
 	// Search ConfigMaps
-	configmaps, err := client.CoreV1().ConfigMaps(namespace).List(context.Background(), metav1.ListOptions{})
-	if err == nil {
-		for _, cm := range configmaps.Items {
-			if strings.Contains(strings.ToLower(cm.Name), query) {
-				results = append(results, SearchResult{
-					Type:      "ConfigMap",
-					Name:      cm.Name,
-					Namespace: cm.Namespace,
-					Age:       formatAge(cm.CreationTimestamp.Time),
-				})
-			}
-			if len(results) >= 50 {
-				break
+	if h.filter.Enabled("configmaps") {
+		configmaps, err := client.CoreV1().ConfigMaps(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err == nil {
+			for _, cm := range configmaps.Items {
+				if strings.Contains(strings.ToLower(cm.Name), query) {
+					results = append(results, SearchResult{
+						Type:      "ConfigMap",
+						Name:      cm.Name,
+						Namespace: cm.Namespace,
+						Age:       formatAge(cm.CreationTimestamp.Time),
+					})
+				}
+				if len(results) >= 50 {
+					break
+				}
 			}
 		}
 	}
 
 	// Search Secrets
-	secrets, err := client.CoreV1().Secrets(namespace).List(context.Background(), metav1.ListOptions{})
-	if err == nil {
-		for _, sec := range secrets.Items {
-			if strings.Contains(strings.ToLower(sec.Name), query) {
-				results = append(results, SearchResult{
-					Type:      "Secret",
-					Name:      sec.Name,
-					Namespace: sec.Namespace,
-					Age:       formatAge(sec.CreationTimestamp.Time),
-				})
-			}
-			if len(results) >= 50 {
-				break
+	if h.filter.Enabled("secrets") {
+		secrets, err := client.CoreV1().Secrets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err == nil {
+			for _, sec := range secrets.Items {
+				if strings.Contains(strings.ToLower(sec.Name), query) {
+					results = append(results, SearchResult{
+						Type:      "Secret",
+						Name:      sec.Name,
+						Namespace: sec.Namespace,
+						Age:       formatAge(sec.CreationTimestamp.Time),
+					})
+				}
+				if len(results) >= 50 {
+					break
+				}
 			}
 		}
 	}
 
 	// Search Ingresses
-	ingresses, err := client.NetworkingV1().Ingresses(namespace).List(context.Background(), metav1.ListOptions{})
-	if err == nil {
-		for _, ing := range ingresses.Items {
-			if strings.Contains(strings.ToLower(ing.Name), query) {
-				results = append(results, SearchResult{
-					Type:      "Ingress",
-					Name:      ing.Name,
-					Namespace: ing.Namespace,
-					Age:       formatAge(ing.CreationTimestamp.Time),
-				})
-			}
-			if len(results) >= 50 {
-				break
+	if h.filter.Enabled("ingresses") {
+		ingresses, err := client.NetworkingV1().Ingresses(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err == nil {
+			for _, ing := range ingresses.Items {
+				if strings.Contains(strings.ToLower(ing.Name), query) {
+					results = append(results, SearchResult{
+						Type:      "Ingress",
+						Name:      ing.Name,
+						Namespace: ing.Namespace,
+						Age:       formatAge(ing.CreationTimestamp.Time),
+					})
+				}
+				if len(results) >= 50 {
+					break
+				}
 			}
 		}
 	}
 
 	// Search DaemonSets
-	daemonsets, err := client.AppsV1().DaemonSets(namespace).List(context.Background(), metav1.ListOptions{})
-	if err == nil {
-		for _, ds := range daemonsets.Items {
-			if strings.Contains(strings.ToLower(ds.Name), query) {
-				results = append(results, SearchResult{
-					Type:      "DaemonSet",
-					Name:      ds.Name,
-					Namespace: ds.Namespace,
-					Age:       formatAge(ds.CreationTimestamp.Time),
-				})
-			}
-			if len(results) >= 50 {
-				break
+	if h.filter.Enabled("daemonsets") {
+		daemonsets, err := client.AppsV1().DaemonSets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err == nil {
+			for _, ds := range daemonsets.Items {
+				if strings.Contains(strings.ToLower(ds.Name), query) {
+					results = append(results, SearchResult{
+						Type:      "DaemonSet",
+						Name:      ds.Name,
+						Namespace: ds.Namespace,
+						Age:       formatAge(ds.CreationTimestamp.Time),
+					})
+				}
+				if len(results) >= 50 {
+					break
+				}
 			}
 		}
 	}
 
 	// Search StatefulSets
-	statefulsets, err := client.AppsV1().StatefulSets(namespace).List(context.Background(), metav1.ListOptions{})
-	if err == nil {
-		for _, ss := range statefulsets.Items {
-			if strings.Contains(strings.ToLower(ss.Name), query) {
-				results = append(results, SearchResult{
-					Type:      "StatefulSet",
-					Name:      ss.Name,
-					Namespace: ss.Namespace,
-					Age:       formatAge(ss.CreationTimestamp.Time),
-				})
-			}
-			if len(results) >= 50 {
-				break
+	if h.filter.Enabled("statefulsets") {
+		statefulsets, err := client.AppsV1().StatefulSets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+		if err == nil {
+			for _, ss := range statefulsets.Items {
+				if strings.Contains(strings.ToLower(ss.Name), query) {
+					results = append(results, SearchResult{
+						Type:      "StatefulSet",
+						Name:      ss.Name,
+						Namespace: ss.Namespace,
+						Age:       formatAge(ss.CreationTimestamp.Time),
+					})
+				}
+				if len(results) >= 50 {
+					break
+				}
 			}
 		}
 	}
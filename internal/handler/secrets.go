@@ -3,8 +3,12 @@ package handler
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/opengittr/kubeui/internal/service"
@@ -28,17 +32,34 @@ type SecretInfo struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 	KeySizes    map[string]int    `json:"keySizes,omitempty"`
 	Data        map[string]string `json:"data,omitempty"` // Decoded secret values
+	Parsed      *SecretParsed     `json:"parsed,omitempty"`
 }
 
+// SecretParsed is a type-aware rendering of well-known secret types
+type SecretParsed struct {
+	Certificates []CertificateInfo `json:"certificates,omitempty"` // kubernetes.io/tls
+	Registries   []string          `json:"registries,omitempty"`   // kubernetes.io/dockerconfigjson and dockercfg
+	Error        string            `json:"error,omitempty"`
+}
+
+// List returns secrets, optionally filtered by the type param, e.g.
+// type=Opaque or type=!service-account-token to hide auto-generated tokens
 func (h *SecretHandler) List(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	fieldSelector, err := secretTypeSelector(ctx.Param("type"))
+	if err != nil {
+		return nil, err
+	}
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	secrets, err := client.CoreV1().Secrets(namespace).List(context.Background(), metav1.ListOptions{})
+	secrets, err := client.CoreV1().Secrets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +83,40 @@ func (h *SecretHandler) List(ctx *gofr.Context) (interface{}, error) {
 	return result, nil
 }
 
+// secretTypeSelector turns a comma-separated type filter into a field selector
+// the API server applies. A leading "!" excludes a type, and names without a
+// "/" other than Opaque are shorthand for kubernetes.io/ types. Field selector
+// terms are ANDed, so at most one type can be included.
+func secretTypeSelector(filter string) (string, error) {
+	if filter == "" {
+		return "", nil
+	}
+
+	var terms []string
+	included := 0
+	for _, t := range strings.Split(filter, ",") {
+		t = strings.TrimSpace(t)
+		op := "="
+		if strings.HasPrefix(t, "!") {
+			op = "!="
+			t = strings.TrimPrefix(t, "!")
+		} else {
+			included++
+		}
+		if t == "" {
+			return "", badRequestError{fmt.Sprintf("invalid type filter %q", filter)}
+		}
+		if t != string(corev1.SecretTypeOpaque) && !strings.Contains(t, "/") {
+			t = "kubernetes.io/" + t
+		}
+		terms = append(terms, "type"+op+t)
+	}
+	if included > 1 {
+		return "", badRequestError{"only one secret type can be included; use ! to exclude others"}
+	}
+	return strings.Join(terms, ","), nil
+}
+
 func (h *SecretHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	namespace := ctx.PathParam("namespace")
 	name := ctx.PathParam("name")
@@ -73,7 +128,7 @@ func (h *SecretHandler) Get(ctx *gofr.Context) (interface{}, error) {
 
 	secret, err := client.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	keys := make([]string, 0, len(secret.Data))
@@ -95,9 +150,99 @@ func (h *SecretHandler) Get(ctx *gofr.Context) (interface{}, error) {
 		Annotations: secret.Annotations,
 		KeySizes:    keySizes,
 		Data:        data,
+		Parsed:      parseSecret(secret),
 	}, nil
 }
 
+// parseSecret renders TLS and registry secrets in a readable form, or nil for other types
+func parseSecret(secret *corev1.Secret) *SecretParsed {
+	var parsed SecretParsed
+	var err error
+
+	switch secret.Type {
+	case corev1.SecretTypeTLS:
+		parsed.Certificates, err = parseCertificates(secret.Data[corev1.TLSCertKey])
+	case corev1.SecretTypeDockerConfigJson:
+		parsed.Registries, err = parseDockerConfigRegistries(secret.Data[corev1.DockerConfigJsonKey], false)
+	case corev1.SecretTypeDockercfg:
+		parsed.Registries, err = parseDockerConfigRegistries(secret.Data[corev1.DockerConfigKey], true)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		parsed.Error = err.Error()
+	}
+	return &parsed
+}
+
+// ExpiringCertificate is a TLS secret whose leaf certificate expires within the requested window
+type ExpiringCertificate struct {
+	Name          string   `json:"name"`
+	Namespace     string   `json:"namespace"`
+	Subject       string   `json:"subject"`
+	DNSNames      []string `json:"dnsNames,omitempty"`
+	NotAfter      string   `json:"notAfter"`
+	DaysRemaining int      `json:"daysRemaining"`
+	Expired       bool     `json:"expired"`
+}
+
+// ExpiringCerts scans kubernetes.io/tls secrets and returns those expiring within
+// the window (days, default 30), soonest first. Expired certificates are included.
+func (h *SecretHandler) ExpiringCerts(ctx *gofr.Context) (interface{}, error) {
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	days := 30
+	if daysParam := ctx.Param("days"); daysParam != "" {
+		n, err := strconv.Atoi(daysParam)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid days %q", daysParam)
+		}
+		days = n
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := client.CoreV1().Secrets(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+		FieldSelector: "type=" + string(corev1.SecretTypeTLS),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []ExpiringCertificate{}
+	for _, secret := range secrets.Items {
+		certs, err := parseCertificates(secret.Data[corev1.TLSCertKey])
+		if err != nil || len(certs) == 0 {
+			continue
+		}
+
+		leaf := certs[0]
+		if leaf.DaysRemaining > days {
+			continue
+		}
+
+		result = append(result, ExpiringCertificate{
+			Name:          secret.Name,
+			Namespace:     secret.Namespace,
+			Subject:       leaf.Subject,
+			DNSNames:      leaf.DNSNames,
+			NotAfter:      leaf.NotAfter,
+			DaysRemaining: leaf.DaysRemaining,
+			Expired:       leaf.DaysRemaining < 0,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DaysRemaining < result[j].DaysRemaining
+	})
+
+	return result, nil
+}
+
 // Events returns events for a specific secret
 func (h *SecretHandler) Events(ctx *gofr.Context) (interface{}, error) {
 	namespace := ctx.PathParam("namespace")
@@ -109,9 +254,9 @@ func (h *SecretHandler) Events(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=Secret", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -3,10 +3,16 @@ package handler
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
+	"time"
 
 	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/opengittr/kubeui/internal/service"
 )
@@ -32,6 +38,21 @@ type ServiceInfo struct {
 	SessionAffinity string            `json:"sessionAffinity,omitempty"`
 	PortDetails     []ServicePort     `json:"portDetails,omitempty"`
 	Endpoints       []ServiceEndpoint `json:"endpoints,omitempty"`
+	// Pods behind the endpoint addresses, matched through targetRef
+	BackingPods []BackingPod `json:"backingPods,omitempty"`
+}
+
+// BackingPod is a pod receiving the service's traffic. Serving is the
+// endpoint's readiness; Ready and Restarts come from the pod itself.
+type BackingPod struct {
+	Name     string `json:"name"`
+	IP       string `json:"ip"`
+	NodeName string `json:"nodeName,omitempty"`
+	Serving  bool   `json:"serving"`
+	Status   string `json:"status,omitempty"`
+	Ready    string `json:"ready,omitempty"`
+	Restarts int32  `json:"restarts"`
+	Missing  bool   `json:"missing,omitempty"` // The endpoint names a pod that no longer exists
 }
 
 type ServicePort struct {
@@ -49,14 +70,14 @@ type ServiceEndpoint struct {
 }
 
 func (h *ServiceHandler) List(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	services, err := client.CoreV1().Services(namespace).List(context.Background(), metav1.ListOptions{})
+	services, err := client.CoreV1().Services(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +151,7 @@ func (h *ServiceHandler) Get(ctx *gofr.Context) (interface{}, error) {
 
 	svc, err := client.CoreV1().Services(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	var ports []string
@@ -194,6 +215,7 @@ func (h *ServiceHandler) Get(ctx *gofr.Context) (interface{}, error) {
 					NodeName: nodeName,
 					Ready:    true,
 				})
+				info.BackingPods = appendBackingPod(info.BackingPods, addr, nodeName, true)
 			}
 			for _, addr := range subset.NotReadyAddresses {
 				nodeName := ""
@@ -205,13 +227,67 @@ func (h *ServiceHandler) Get(ctx *gofr.Context) (interface{}, error) {
 					NodeName: nodeName,
 					Ready:    false,
 				})
+				info.BackingPods = appendBackingPod(info.BackingPods, addr, nodeName, false)
 			}
 		}
+		h.fillBackingPods(namespace, svc.Spec.Selector, info.BackingPods)
 	}
 
 	return info, nil
 }
 
+// appendBackingPod adds the pod an endpoint address points at, if it names one
+func appendBackingPod(pods []BackingPod, addr corev1.EndpointAddress, nodeName string, serving bool) []BackingPod {
+	if addr.TargetRef == nil || addr.TargetRef.Kind != "Pod" {
+		return pods
+	}
+	return append(pods, BackingPod{Name: addr.TargetRef.Name, IP: addr.IP, NodeName: nodeName, Serving: serving})
+}
+
+// fillBackingPods adds each pod's status, readiness and restarts. Pods are
+// listed by the service selector; selectorless services fall back to a Get per pod.
+func (h *ServiceHandler) fillBackingPods(namespace string, selector map[string]string, backing []BackingPod) {
+	if len(backing) == 0 {
+		return
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return
+	}
+
+	pods := make(map[string]*corev1.Pod)
+	if len(selector) > 0 {
+		list, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(selector).String(),
+		}))
+		if err == nil {
+			for i := range list.Items {
+				pods[list.Items[i].Name] = &list.Items[i]
+			}
+		}
+	}
+
+	for i := range backing {
+		pod, ok := pods[backing[i].Name]
+		if !ok {
+			pod, err = client.CoreV1().Pods(namespace).Get(context.Background(), backing[i].Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				backing[i].Missing = true
+				continue
+			}
+			if err != nil {
+				continue
+			}
+		}
+
+		info := podToInfo(pod, false)
+		backing[i].Status = info.Status
+		backing[i].Ready = info.Ready
+		backing[i].Restarts = info.Restarts
+	}
+}
+
 // Events returns events for a specific service
 func (h *ServiceHandler) Events(ctx *gofr.Context) (interface{}, error) {
 	namespace := ctx.PathParam("namespace")
@@ -223,9 +299,9 @@ func (h *ServiceHandler) Events(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=Service", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -258,3 +334,130 @@ func (h *ServiceHandler) Events(ctx *gofr.Context) (interface{}, error) {
 
 	return result, nil
 }
+
+// probeImage is the image used for the throwaway pod that probes a service
+const probeImage = "curlimages/curl:8.10.1"
+
+type probeRequest struct {
+	Port int32  `json:"port"` // defaults to the service's first port
+	Path string `json:"path"` // defaults to "/"
+}
+
+type ProbeResult struct {
+	URL        string  `json:"url"`
+	Reachable  bool    `json:"reachable"`
+	StatusCode int     `json:"statusCode,omitempty"`
+	LatencyMs  float64 `json:"latencyMs,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	ProbePod   string  `json:"probePod"`
+}
+
+// Probe runs a short-lived pod inside the service's namespace that curls the service,
+// then reports the HTTP status and latency. The pod is deleted afterwards.
+func (h *ServiceHandler) Probe(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	var req probeRequest
+	if err := ctx.Bind(&req); err != nil {
+		return nil, err
+	}
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := client.CoreV1().Services(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	port := req.Port
+	if port == 0 {
+		if len(svc.Spec.Ports) == 0 {
+			return nil, fmt.Errorf("service %s exposes no ports", name)
+		}
+		port = svc.Spec.Ports[0].Port
+	}
+
+	// Headless services have no ClusterIP, so go through cluster DNS instead
+	host := svc.Spec.ClusterIP
+	if host == "" || host == corev1.ClusterIPNone {
+		host = fmt.Sprintf("%s.%s.svc", name, namespace)
+	}
+	probePath := "/" + strings.TrimPrefix(req.Path, "/")
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(host, strconv.Itoa(int(port))), probePath)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "kubeui-probe-",
+			Namespace:    namespace,
+			Labels:       map[string]string{"app.kubernetes.io/created-by": "kubeui"},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:  "probe",
+				Image: probeImage,
+				Args: []string{
+					"-s", "-o", "/dev/null",
+					"--max-time", "10",
+					"-w", "%{http_code} %{time_total}",
+					url,
+				},
+			}},
+		},
+	}
+
+	created, err := client.CoreV1().Pods(namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create probe pod: %w", err)
+	}
+	defer func() {
+		_ = client.CoreV1().Pods(namespace).Delete(context.Background(), created.Name, metav1.DeleteOptions{})
+	}()
+
+	result := ProbeResult{URL: url, ProbePod: created.Name}
+
+	// Wait for the probe pod to finish (image pull included)
+	waitCtx, cancel := context.WithTimeout(ctx, 90*time.Second)
+	defer cancel()
+
+	var phase corev1.PodPhase
+	for phase != corev1.PodSucceeded && phase != corev1.PodFailed {
+		select {
+		case <-waitCtx.Done():
+			result.Error = "timed out waiting for probe pod to complete"
+			return result, nil
+		case <-time.After(time.Second):
+		}
+
+		p, err := client.CoreV1().Pods(namespace).Get(waitCtx, created.Name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		phase = p.Status.Phase
+	}
+
+	logs, err := client.CoreV1().Pods(namespace).GetLogs(created.Name, &corev1.PodLogOptions{}).DoRaw(context.Background())
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to read probe output: %v", err)
+		return result, nil
+	}
+
+	// Output is "<http_code> <time_total seconds>"; code 000 means no HTTP response
+	fields := strings.Fields(string(logs))
+	if len(fields) == 2 {
+		result.StatusCode, _ = strconv.Atoi(fields[0])
+		if seconds, err := strconv.ParseFloat(fields[1], 64); err == nil {
+			result.LatencyMs = seconds * 1000
+		}
+	}
+	result.Reachable = result.StatusCode > 0
+	if !result.Reachable {
+		result.Error = "no HTTP response from service"
+	}
+
+	return result, nil
+}
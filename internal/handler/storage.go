@@ -2,9 +2,13 @@ package handler
 
 import (
 	"context"
+	"fmt"
 
 	"gofr.dev/pkg/gofr"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/opengittr/kubeui/internal/service"
 )
@@ -37,6 +41,9 @@ type PVCInfo struct {
 	AccessModes  string `json:"accessModes"`
 	StorageClass string `json:"storageClass"`
 	Age          string `json:"age"`
+	// Set while the claim is terminating, e.g. held by kubernetes.io/pvc-protection
+	Finalizers        []string `json:"finalizers,omitempty"`
+	DeletionTimestamp string   `json:"deletionTimestamp,omitempty"`
 }
 
 func (h *StorageHandler) ListPVs(ctx *gofr.Context) (interface{}, error) {
@@ -45,7 +52,7 @@ func (h *StorageHandler) ListPVs(ctx *gofr.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	pvs, err := client.CoreV1().PersistentVolumes().List(context.Background(), metav1.ListOptions{})
+	pvs, err := client.CoreV1().PersistentVolumes().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +108,7 @@ func (h *StorageHandler) ListStorageClasses(ctx *gofr.Context) (interface{}, err
 		return nil, err
 	}
 
-	scs, err := client.StorageV1().StorageClasses().List(context.Background(), metav1.ListOptions{})
+	scs, err := client.StorageV1().StorageClasses().List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -143,14 +150,14 @@ func (h *StorageHandler) ListStorageClasses(ctx *gofr.Context) (interface{}, err
 }
 
 func (h *StorageHandler) ListPVCs(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	pvcs, err := client.CoreV1().PersistentVolumeClaims(namespace).List(context.Background(), metav1.ListOptions{})
+	pvcs, err := client.CoreV1().PersistentVolumeClaims(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -186,8 +193,144 @@ func (h *StorageHandler) ListPVCs(ctx *gofr.Context) (interface{}, error) {
 			AccessModes:  accessModes,
 			StorageClass: storageClass,
 			Age:          formatAge(pvc.CreationTimestamp.Time),
+
+			Finalizers:        pvc.Finalizers,
+			DeletionTimestamp: formatDeletionTimestamp(pvc.DeletionTimestamp),
 		})
 	}
 
 	return result, nil
 }
+
+// defaultStorageClassAnnotation marks the class used by claims that don't name one
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// PVCDiagnosis gathers the clues for why a claim is or isn't bound
+type PVCDiagnosis struct {
+	Name               string         `json:"name"`
+	Namespace          string         `json:"namespace"`
+	Status             string         `json:"status"`
+	Volume             string         `json:"volume,omitempty"`
+	StorageClass       string         `json:"storageClass"` // "" means static binding only
+	StorageClassExists bool           `json:"storageClassExists"`
+	Provisioner        string         `json:"provisioner,omitempty"`
+	VolumeBindingMode  string         `json:"volumeBindingMode,omitempty"`
+	Conditions         []PVCCondition `json:"conditions"`
+	Events             []EventInfo    `json:"events"`
+	Hints              []string       `json:"hints"`
+}
+
+type PVCCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// DiagnosePVC returns a claim's conditions, events and StorageClass details
+// with hints for common reasons a claim stays Pending
+func (h *StorageHandler) DiagnosePVC(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	name := ctx.PathParam("name")
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := PVCDiagnosis{
+		Name:       pvc.Name,
+		Namespace:  pvc.Namespace,
+		Status:     string(pvc.Status.Phase),
+		Volume:     pvc.Spec.VolumeName,
+		Conditions: []PVCCondition{},
+		Events:     []EventInfo{},
+		Hints:      []string{},
+	}
+
+	for _, cond := range pvc.Status.Conditions {
+		result.Conditions = append(result.Conditions, PVCCondition{
+			Type:    string(cond.Type),
+			Status:  string(cond.Status),
+			Reason:  cond.Reason,
+			Message: cond.Message,
+		})
+	}
+
+	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=PersistentVolumeClaim", name, namespace)
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range events.Items {
+		result.Events = append(result.Events, eventToInfo(&events.Items[i]))
+	}
+
+	var storageClass *storagev1.StorageClass
+	switch {
+	case pvc.Spec.StorageClassName == nil:
+		// No class requested and no default existed at creation; a default added later is applied retroactively
+		storageClass, err = findDefaultStorageClass(client)
+		if err != nil {
+			return nil, err
+		}
+		if storageClass == nil {
+			result.Hints = append(result.Hints, "The claim requests no StorageClass and the cluster has no default; it can only bind to a pre-created PersistentVolume")
+		}
+	case *pvc.Spec.StorageClassName == "":
+		result.Hints = append(result.Hints, "The claim disables dynamic provisioning (storageClassName \"\"); it can only bind to a pre-created PersistentVolume without a class")
+	default:
+		storageClass, err = client.StorageV1().StorageClasses().Get(context.Background(), *pvc.Spec.StorageClassName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			result.StorageClass = *pvc.Spec.StorageClassName
+			result.Hints = append(result.Hints, fmt.Sprintf("StorageClass %q does not exist", *pvc.Spec.StorageClassName))
+			storageClass, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if storageClass != nil {
+		result.StorageClass = storageClass.Name
+		result.StorageClassExists = true
+		result.Provisioner = storageClass.Provisioner
+		result.VolumeBindingMode = string(storagev1.VolumeBindingImmediate)
+		if storageClass.VolumeBindingMode != nil {
+			result.VolumeBindingMode = string(*storageClass.VolumeBindingMode)
+		}
+
+		if pvc.Status.Phase == "Pending" && result.VolumeBindingMode == string(storagev1.VolumeBindingWaitForFirstConsumer) {
+			result.Hints = append(result.Hints, "The StorageClass uses WaitForFirstConsumer; the volume is provisioned only once a pod using the claim is scheduled")
+		}
+	}
+
+	for _, event := range result.Events {
+		if event.Type == "Warning" && (event.Reason == "ProvisioningFailed" || event.Reason == "FailedBinding") {
+			result.Hints = append(result.Hints, fmt.Sprintf("%s: %s", event.Reason, event.Message))
+		}
+	}
+
+	return result, nil
+}
+
+// findDefaultStorageClass returns the cluster's default StorageClass, or nil if there is none
+func findDefaultStorageClass(client *kubernetes.Clientset) (*storagev1.StorageClass, error) {
+	classes, err := client.StorageV1().StorageClasses().List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range classes.Items {
+		if classes.Items[i].Annotations[defaultStorageClassAnnotation] == "true" {
+			return &classes.Items[i], nil
+		}
+	}
+	return nil, nil
+}
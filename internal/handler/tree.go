@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/opengittr/kubeui/internal/service"
+)
+
+// TreeHandler resolves ownership trees rooted at a controller
+type TreeHandler struct {
+	k8s *service.K8sManager
+}
+
+func NewTreeHandler(k8s *service.K8sManager) *TreeHandler {
+	return &TreeHandler{k8s: k8s}
+}
+
+// OwnerTreeNode is one object in an ownership tree
+type OwnerTreeNode struct {
+	Kind      string          `json:"kind"`
+	Name      string          `json:"name"`
+	Namespace string          `json:"namespace"`
+	Status    string          `json:"status,omitempty"`
+	Age       string          `json:"age"`
+	Children  []OwnerTreeNode `json:"children,omitempty"`
+}
+
+// Get returns the ownership tree rooted at the given object, e.g.
+// Deployment→ReplicaSets→Pods or CronJob→Jobs→Pods. kind is a resource path
+// name such as "deployments".
+func (h *TreeHandler) Get(ctx *gofr.Context) (interface{}, error) {
+	namespace := ctx.PathParam("namespace")
+	kind := ctx.PathParam("kind")
+	name := ctx.PathParam("name")
+
+	gvr, err := gvrFor(strings.ToLower(kind))
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := resourceClient(h.k8s, gvr, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := resource.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return lookupError(err)
+	}
+
+	children, err := h.indexChildren(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	rootNode := OwnerTreeNode{
+		Kind:      root.GetKind(),
+		Name:      root.GetName(),
+		Namespace: root.GetNamespace(),
+		Age:       formatAge(root.GetCreationTimestamp().Time),
+	}
+	return buildOwnerTree(rootNode, root.GetUID(), children, map[types.UID]bool{}), nil
+}
+
+// ownedObject is a candidate child with the node to render for it
+type ownedObject struct {
+	uid  types.UID
+	node OwnerTreeNode
+}
+
+// indexChildren lists the kinds controllers create in a namespace, keyed by owner UID
+func (h *TreeHandler) indexChildren(namespace string) (map[types.UID][]ownedObject, error) {
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[types.UID][]ownedObject)
+	add := func(meta metav1.Object, kind, status string) {
+		for _, ref := range meta.GetOwnerReferences() {
+			children[ref.UID] = append(children[ref.UID], ownedObject{
+				uid: meta.GetUID(),
+				node: OwnerTreeNode{
+					Kind:      kind,
+					Name:      meta.GetName(),
+					Namespace: meta.GetNamespace(),
+					Status:    status,
+					Age:       formatAge(meta.GetCreationTimestamp().Time),
+				},
+			})
+		}
+	}
+
+	replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		desired := int32(0)
+		if rs.Spec.Replicas != nil {
+			desired = *rs.Spec.Replicas
+		}
+		add(rs, "ReplicaSet", fmt.Sprintf("%d/%d ready", rs.Status.ReadyReplicas, desired))
+	}
+
+	jobs, err := client.BatchV1().Jobs(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		status := "Running"
+		for _, c := range job.Status.Conditions {
+			if (c.Type == "Complete" || c.Type == "Failed") && c.Status == "True" {
+				status = string(c.Type)
+			}
+		}
+		add(job, "Job", status)
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		add(pod, "Pod", string(pod.Status.Phase))
+	}
+
+	return children, nil
+}
+
+// buildOwnerTree attaches the children of uid to node recursively. visited guards against ownership cycles.
+func buildOwnerTree(node OwnerTreeNode, uid types.UID, children map[types.UID][]ownedObject, visited map[types.UID]bool) OwnerTreeNode {
+	if visited[uid] {
+		return node
+	}
+	visited[uid] = true
+
+	for _, child := range children[uid] {
+		node.Children = append(node.Children, buildOwnerTree(child.node, child.uid, children, visited))
+	}
+	return node
+}
@@ -1,13 +1,20 @@
 package handler
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 
 	"github.com/opengittr/kubeui/internal/service"
 )
@@ -19,7 +26,7 @@ type SSEHandler struct {
 
 // SSEMessage represents a message sent via SSE
 type SSEMessage struct {
-	Type      string      `json:"type"`      // "update", "error"
+	Type      string      `json:"type"`      // "update", "event", "error"
 	Resource  string      `json:"resource"`  // Resource type: pods, deployments, etc.
 	Namespace string      `json:"namespace"` // Namespace filter
 	Data      interface{} `json:"data,omitempty"`
@@ -52,14 +59,14 @@ func NewSSEHandler(k8sManager *service.K8sManager) *SSEHandler {
 // Stream handles SSE streaming of resource updates
 func (h *SSEHandler) Stream(ctx *gofr.Context) (interface{}, error) {
 	resource := ctx.Param("resource")
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8sManager).Namespace
 
 	if resource == "" {
 		resource = "pods" // Default to pods
 	}
 
 	// Get initial data
-	data, err := h.fetchResource(resource, namespace)
+	data, err := h.fetchResource(resource, namespace, newSummaryOptions(ctx.Param("consistent"), ctx.Param("limit")))
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +80,8 @@ func (h *SSEHandler) Stream(ctx *gofr.Context) (interface{}, error) {
 
 // Summary returns a summary of all resources for the dashboard
 func (h *SSEHandler) Summary(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8sManager).Namespace
+	options := newSummaryOptions(ctx.Param("consistent"), ctx.Param("limit"))
 	apiCtx := context.Background()
 
 	client, err := h.k8sManager.GetClient()
@@ -98,13 +106,13 @@ func (h *SSEHandler) Summary(ctx *gofr.Context) (interface{}, error) {
 
 			switch r {
 			case "pods":
-				data, err = fetchPodsSummary(client, namespace, apiCtx)
+				data, err = fetchPodsSummary(client, namespace, options, apiCtx)
 			case "deployments":
-				data, err = fetchDeploymentsSummary(client, namespace, apiCtx)
+				data, err = fetchDeploymentsSummary(client, namespace, options, apiCtx)
 			case "services":
-				data, err = fetchServicesSummary(client, namespace, apiCtx)
+				data, err = h.servicesSummary(client, namespace, options, apiCtx)
 			case "nodes":
-				data, err = fetchNodesSummary(client, apiCtx)
+				data, err = fetchNodesSummary(client, options, apiCtx)
 			}
 
 			resultChan <- result{name: r, data: data, err: err}
@@ -122,7 +130,32 @@ func (h *SSEHandler) Summary(ctx *gofr.Context) (interface{}, error) {
 	return summary, nil
 }
 
-func (h *SSEHandler) fetchResource(resource, namespace string) (interface{}, error) {
+// servicesSummary counts services through the metadata client when no items
+// are requested. Unlike pods, deployments and nodes, their health breakdown
+// doesn't depend on status, so only the item list needs full objects.
+func (h *SSEHandler) servicesSummary(client *kubernetes.Clientset, namespace string, options summaryOptions, apiCtx context.Context) (*ResourceSummary, error) {
+	if options.itemLimit(defaultSummaryItems) > 0 {
+		return fetchServicesSummary(client, namespace, options, apiCtx)
+	}
+
+	config, err := h.k8sManager.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	metadataClient, err := metadata.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := fetchCountSummary(metadataClient, corev1.SchemeGroupVersion.WithResource("services"), namespace, options, apiCtx)
+	if err != nil {
+		return nil, err
+	}
+	summary.Healthy = summary.Total
+	return summary, nil
+}
+
+func (h *SSEHandler) fetchResource(resource, namespace string, options summaryOptions) (interface{}, error) {
 	client, err := h.k8sManager.GetClient()
 	if err != nil {
 		return nil, err
@@ -132,25 +165,27 @@ func (h *SSEHandler) fetchResource(resource, namespace string) (interface{}, err
 
 	switch resource {
 	case "pods":
-		return fetchPodsSummary(client, namespace, apiCtx)
+		return fetchPodsSummary(client, namespace, options, apiCtx)
 	case "deployments":
-		return fetchDeploymentsSummary(client, namespace, apiCtx)
+		return fetchDeploymentsSummary(client, namespace, options, apiCtx)
 	case "services":
-		return fetchServicesSummary(client, namespace, apiCtx)
+		return h.servicesSummary(client, namespace, options, apiCtx)
 	case "nodes":
-		return fetchNodesSummary(client, apiCtx)
+		return fetchNodesSummary(client, options, apiCtx)
 	case "events":
-		return fetchEventsSummary(client, namespace, apiCtx)
+		return fetchEventsSummary(client, namespace, options, apiCtx)
 	default:
 		return nil, fmt.Errorf("unknown resource type: %s", resource)
 	}
 }
 
-// SSEMiddleware creates an HTTP handler for SSE streaming
+// SSEMiddleware creates an HTTP handler for SSE streaming. /api/events/stream
+// pushes a polled summary of ?resource (pods by default) every few seconds;
+// /api/events/watch pushes each new Kubernetes event as it happens.
 func (h *SSEHandler) SSEMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check if this is an SSE request
-		if r.URL.Path != "/api/events/stream" {
+		if r.URL.Path != "/api/events/stream" && r.URL.Path != "/api/events/watch" {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -167,8 +202,26 @@ func (h *SSEHandler) SSEMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// Summary payloads repeat every few seconds, so compress them when the client allows it
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			gz := gzip.NewWriter(w)
+			defer gz.Close()
+
+			gzw := &gzipFlushWriter{ResponseWriter: w, gz: gz, flusher: flusher}
+			w, flusher = gzw, gzw
+		}
+
 		resource := r.URL.Query().Get("resource")
 		namespace := r.URL.Query().Get("namespace")
+		options := newSummaryOptions(r.URL.Query().Get("consistent"), r.URL.Query().Get("limit"))
+
+		if r.URL.Path == "/api/events/watch" {
+			h.watchEvents(w, flusher, r, namespace)
+			return
+		}
 
 		if resource == "" {
 			resource = "pods"
@@ -179,21 +232,131 @@ func (h *SSEHandler) SSEMiddleware(next http.Handler) http.Handler {
 		defer ticker.Stop()
 
 		// Send initial data immediately
-		h.sendUpdate(w, flusher, resource, namespace)
+		h.sendUpdate(w, flusher, resource, namespace, options)
 
 		for {
 			select {
 			case <-r.Context().Done():
 				return
 			case <-ticker.C:
-				h.sendUpdate(w, flusher, resource, namespace)
+				h.sendUpdate(w, flusher, resource, namespace, options)
 			}
 		}
 	})
 }
 
-func (h *SSEHandler) sendUpdate(w http.ResponseWriter, flusher http.Flusher, resource, namespace string) {
-	data, err := h.fetchResource(resource, namespace)
+// watchEvents pushes each new Kubernetes event as an SSE message until the client disconnects
+func (h *SSEHandler) watchEvents(w http.ResponseWriter, flusher http.Flusher, r *http.Request, namespace string) {
+	ctx := r.Context()
+
+	client, err := h.k8sManager.GetStreamingClient()
+	if err != nil {
+		sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "events", Data: err.Error()})
+		return
+	}
+
+	// Start watching from the current list so only new events are pushed
+	list, err := client.CoreV1().Events(namespace).List(ctx, listOptions(metav1.ListOptions{Limit: 1}))
+	if err != nil {
+		sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "events", Data: err.Error()})
+		return
+	}
+	resourceVersion := list.ResourceVersion
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		watcher, err := client.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "events", Data: err.Error()})
+			return
+		}
+
+		expired := false
+	consume:
+		for {
+			select {
+			case <-ctx.Done():
+				watcher.Stop()
+				return
+			case <-keepAlive.C:
+				fmt.Fprint(w, ": keep-alive\n\n")
+				flusher.Flush()
+			case ev, ok := <-watcher.ResultChan():
+				if !ok {
+					break consume
+				}
+
+				switch ev.Type {
+				case watch.Error:
+					// Typically 410 Gone: our resourceVersion is too old, restart from now
+					expired = true
+					break consume
+				case watch.Added, watch.Modified:
+					event, ok := ev.Object.(*corev1.Event)
+					if !ok {
+						continue
+					}
+					resourceVersion = event.ResourceVersion
+					sendSSEMessage(w, flusher, SSEMessage{
+						Type:      "event",
+						Resource:  "events",
+						Namespace: namespace,
+						Data:      eventToInfo(event),
+					})
+				case watch.Bookmark:
+					if obj, ok := ev.Object.(*corev1.Event); ok {
+						resourceVersion = obj.ResourceVersion
+					}
+				}
+			}
+		}
+		watcher.Stop()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if expired {
+			list, err := client.CoreV1().Events(namespace).List(ctx, listOptions(metav1.ListOptions{Limit: 1}))
+			if err != nil {
+				sendSSEMessage(w, flusher, SSEMessage{Type: "error", Resource: "events", Data: err.Error()})
+				return
+			}
+			resourceVersion = list.ResourceVersion
+		}
+	}
+}
+
+// gzipFlushWriter gzips an SSE stream, flushing a complete gzip block with each
+// event so the browser can decode it immediately
+type gzipFlushWriter struct {
+	http.ResponseWriter
+	gz      *gzip.Writer
+	flusher http.Flusher
+}
+
+func (g *gzipFlushWriter) Write(p []byte) (int, error) {
+	return g.gz.Write(p)
+}
+
+func (g *gzipFlushWriter) Flush() {
+	_ = g.gz.Flush()
+	g.flusher.Flush()
+}
+
+func sendSSEMessage(w http.ResponseWriter, flusher http.Flusher, msg SSEMessage) {
+	jsonData, _ := json.Marshal(msg)
+	fmt.Fprintf(w, "data: %s\n\n", jsonData)
+	flusher.Flush()
+}
+
+func (h *SSEHandler) sendUpdate(w http.ResponseWriter, flusher http.Flusher, resource, namespace string, options summaryOptions) {
+	data, err := h.fetchResource(resource, namespace, options)
 	if err != nil {
 		msg := SSEMessage{
 			Type:     "error",
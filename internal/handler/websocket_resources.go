@@ -3,16 +3,52 @@ package handler
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 )
 
-func fetchPodsSummary(client *kubernetes.Clientset, namespace string, ctx context.Context) (*ResourceSummary, error) {
-	opts := metav1.ListOptions{}
+const (
+	// defaultSummaryItems is how many items a summary includes per resource unless a limit is given
+	defaultSummaryItems = 50
+	// defaultEventSummaryItems is the default item count for the recent events summary
+	defaultEventSummaryItems = 20
+	// maxSummaryItems caps the per-resource item limit a client may request
+	maxSummaryItems = 500
+)
+
+// summaryOptions are per-request settings for summary and stream fetches
+type summaryOptions struct {
+	consistent bool // Read from etcd instead of the watch cache
+	limit      int  // Items per resource; negative means the resource's default
+}
+
+// newSummaryOptions parses the consistent and limit query parameters. An
+// invalid limit falls back to the defaults; limit=0 returns counts without items.
+func newSummaryOptions(consistent, limit string) summaryOptions {
+	options := summaryOptions{consistent: consistent == "true", limit: -1}
+	if n, err := strconv.Atoi(limit); err == nil && n >= 0 {
+		options.limit = minInt(n, maxSummaryItems)
+	}
+	return options
+}
+
+// itemLimit returns the requested item limit, or def when none was given
+func (o summaryOptions) itemLimit(def int) int {
+	if o.limit < 0 {
+		return def
+	}
+	return o.limit
+}
+
+func fetchPodsSummary(client *kubernetes.Clientset, namespace string, options summaryOptions, ctx context.Context) (*ResourceSummary, error) {
+	opts := cachedListOptions(metav1.ListOptions{}, options.consistent)
 	var pods *corev1.PodList
 	var err error
 
@@ -25,9 +61,10 @@ func fetchPodsSummary(client *kubernetes.Clientset, namespace string, ctx contex
 		return nil, err
 	}
 
+	limit := options.itemLimit(defaultSummaryItems)
 	summary := &ResourceSummary{
 		Total: len(pods.Items),
-		Items: make([]ResourceItem, 0, minInt(len(pods.Items), 50)),
+		Items: make([]ResourceItem, 0, minInt(len(pods.Items), limit)),
 	}
 
 	for i, pod := range pods.Items {
@@ -42,7 +79,7 @@ func fetchPodsSummary(client *kubernetes.Clientset, namespace string, ctx contex
 			summary.Error++
 		}
 
-		if i < 50 {
+		if i < limit {
 			ready := "0/0"
 			readyCount := 0
 			totalContainers := len(pod.Spec.Containers)
@@ -66,8 +103,8 @@ func fetchPodsSummary(client *kubernetes.Clientset, namespace string, ctx contex
 	return summary, nil
 }
 
-func fetchDeploymentsSummary(client *kubernetes.Clientset, namespace string, ctx context.Context) (*ResourceSummary, error) {
-	opts := metav1.ListOptions{}
+func fetchDeploymentsSummary(client *kubernetes.Clientset, namespace string, options summaryOptions, ctx context.Context) (*ResourceSummary, error) {
+	opts := cachedListOptions(metav1.ListOptions{}, options.consistent)
 	var deployments *appsv1.DeploymentList
 	var err error
 
@@ -80,9 +117,10 @@ func fetchDeploymentsSummary(client *kubernetes.Clientset, namespace string, ctx
 		return nil, err
 	}
 
+	limit := options.itemLimit(defaultSummaryItems)
 	summary := &ResourceSummary{
 		Total: len(deployments.Items),
-		Items: make([]ResourceItem, 0, minInt(len(deployments.Items), 50)),
+		Items: make([]ResourceItem, 0, minInt(len(deployments.Items), limit)),
 	}
 
 	for i, deploy := range deployments.Items {
@@ -104,7 +142,7 @@ func fetchDeploymentsSummary(client *kubernetes.Clientset, namespace string, ctx
 			status = "Unavailable"
 		}
 
-		if i < 50 {
+		if i < limit {
 			summary.Items = append(summary.Items, ResourceItem{
 				Name:      deploy.Name,
 				Namespace: deploy.Namespace,
@@ -118,8 +156,8 @@ func fetchDeploymentsSummary(client *kubernetes.Clientset, namespace string, ctx
 	return summary, nil
 }
 
-func fetchServicesSummary(client *kubernetes.Clientset, namespace string, ctx context.Context) (*ResourceSummary, error) {
-	opts := metav1.ListOptions{}
+func fetchServicesSummary(client *kubernetes.Clientset, namespace string, options summaryOptions, ctx context.Context) (*ResourceSummary, error) {
+	opts := cachedListOptions(metav1.ListOptions{}, options.consistent)
 	var services *corev1.ServiceList
 	var err error
 
@@ -132,14 +170,15 @@ func fetchServicesSummary(client *kubernetes.Clientset, namespace string, ctx co
 		return nil, err
 	}
 
+	limit := options.itemLimit(defaultSummaryItems)
 	summary := &ResourceSummary{
 		Total:   len(services.Items),
 		Healthy: len(services.Items),
-		Items:   make([]ResourceItem, 0, minInt(len(services.Items), 50)),
+		Items:   make([]ResourceItem, 0, minInt(len(services.Items), limit)),
 	}
 
 	for i, svc := range services.Items {
-		if i < 50 {
+		if i < limit {
 			summary.Items = append(summary.Items, ResourceItem{
 				Name:      svc.Name,
 				Namespace: svc.Namespace,
@@ -152,18 +191,20 @@ func fetchServicesSummary(client *kubernetes.Clientset, namespace string, ctx co
 	return summary, nil
 }
 
-func fetchNodesSummary(client *kubernetes.Clientset, ctx context.Context) (*ResourceSummary, error) {
-	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+func fetchNodesSummary(client *kubernetes.Clientset, options summaryOptions, ctx context.Context) (*ResourceSummary, error) {
+	nodes, err := client.CoreV1().Nodes().List(ctx, cachedListOptions(metav1.ListOptions{}, options.consistent))
 	if err != nil {
 		return nil, err
 	}
 
+	// Nodes are few, so all are listed unless a limit is requested
+	limit := options.itemLimit(len(nodes.Items))
 	summary := &ResourceSummary{
 		Total: len(nodes.Items),
-		Items: make([]ResourceItem, 0, len(nodes.Items)),
+		Items: make([]ResourceItem, 0, minInt(len(nodes.Items), limit)),
 	}
 
-	for _, node := range nodes.Items {
+	for i, node := range nodes.Items {
 		status := "Unknown"
 		for _, condition := range node.Status.Conditions {
 			if condition.Type == corev1.NodeReady {
@@ -178,18 +219,20 @@ func fetchNodesSummary(client *kubernetes.Clientset, ctx context.Context) (*Reso
 			}
 		}
 
-		summary.Items = append(summary.Items, ResourceItem{
-			Name:   node.Name,
-			Status: status,
-			Age:    formatAgeDuration(node.CreationTimestamp.Time),
-		})
+		if i < limit {
+			summary.Items = append(summary.Items, ResourceItem{
+				Name:   node.Name,
+				Status: status,
+				Age:    formatAgeDuration(node.CreationTimestamp.Time),
+			})
+		}
 	}
 
 	return summary, nil
 }
 
-func fetchEventsSummary(client *kubernetes.Clientset, namespace string, ctx context.Context) (*ResourceSummary, error) {
-	opts := metav1.ListOptions{}
+func fetchEventsSummary(client *kubernetes.Clientset, namespace string, options summaryOptions, ctx context.Context) (*ResourceSummary, error) {
+	opts := cachedListOptions(metav1.ListOptions{}, options.consistent)
 	var events *corev1.EventList
 	var err error
 
@@ -203,8 +246,9 @@ func fetchEventsSummary(client *kubernetes.Clientset, namespace string, ctx cont
 	}
 
 	cutoff := time.Now().Add(-5 * time.Minute)
+	limit := options.itemLimit(defaultEventSummaryItems)
 	summary := &ResourceSummary{
-		Items: make([]ResourceItem, 0, 20),
+		Items: make([]ResourceItem, 0, limit),
 	}
 
 	for _, event := range events.Items {
@@ -221,7 +265,7 @@ func fetchEventsSummary(client *kubernetes.Clientset, namespace string, ctx cont
 				summary.Healthy++
 			}
 
-			if len(summary.Items) < 20 {
+			if len(summary.Items) < limit {
 				summary.Items = append(summary.Items, ResourceItem{
 					Name:      event.InvolvedObject.Name,
 					Namespace: event.Namespace,
@@ -235,6 +279,17 @@ func fetchEventsSummary(client *kubernetes.Clientset, namespace string, ctx cont
 	return summary, nil
 }
 
+// fetchCountSummary counts a resource using metadata-only (PartialObjectMetadata)
+// lists, so specs and statuses are never transferred. Only Total is set.
+func fetchCountSummary(client metadata.Interface, gvr schema.GroupVersionResource, namespace string, options summaryOptions, ctx context.Context) (*ResourceSummary, error) {
+	list, err := client.Resource(gvr).Namespace(namespace).List(ctx, cachedListOptions(metav1.ListOptions{}, options.consistent))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResourceSummary{Total: len(list.Items)}, nil
+}
+
 func formatAgeDuration(t time.Time) string {
 	d := time.Since(t)
 	if d < time.Minute {
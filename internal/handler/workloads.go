@@ -2,10 +2,12 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"gofr.dev/pkg/gofr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/opengittr/kubeui/internal/service"
@@ -63,14 +65,14 @@ type DaemonSetRunningContainer struct {
 }
 
 func (h *WorkloadHandler) ListDaemonSets(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	daemonsets, err := client.AppsV1().DaemonSets(namespace).List(context.Background(), metav1.ListOptions{})
+	daemonsets, err := client.AppsV1().DaemonSets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +118,7 @@ func (h *WorkloadHandler) GetDaemonSet(ctx *gofr.Context) (interface{}, error) {
 
 	ds, err := client.AppsV1().DaemonSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	nodeSelector := ""
@@ -195,9 +197,9 @@ func (h *WorkloadHandler) fetchDaemonSetRunningContainers(namespace string, sele
 		return nil
 	}
 
-	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		LabelSelector: labelSelector,
-	})
+	}))
 	if err != nil {
 		return nil
 	}
@@ -206,9 +208,9 @@ func (h *WorkloadHandler) fetchDaemonSetRunningContainers(namespace string, sele
 	metricsMap := make(map[string]map[string]ContainerResource)
 	mc, err := h.k8s.GetMetricsClient()
 	if err == nil {
-		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), metav1.ListOptions{
+		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 			LabelSelector: labelSelector,
-		})
+		}))
 		if err == nil {
 			for _, pm := range podMetrics.Items {
 				if metricsMap[pm.Name] == nil {
@@ -286,9 +288,9 @@ func (h *WorkloadHandler) DaemonSetEvents(ctx *gofr.Context) (interface{}, error
 	}
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=DaemonSet", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -365,14 +367,14 @@ type StatefulSetRunningContainer struct {
 }
 
 func (h *WorkloadHandler) ListStatefulSets(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	statefulsets, err := client.AppsV1().StatefulSets(namespace).List(context.Background(), metav1.ListOptions{})
+	statefulsets, err := client.AppsV1().StatefulSets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -408,7 +410,7 @@ func (h *WorkloadHandler) GetStatefulSet(ctx *gofr.Context) (interface{}, error)
 
 	ss, err := client.AppsV1().StatefulSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	replicas := int32(0)
@@ -481,9 +483,9 @@ func (h *WorkloadHandler) fetchStatefulSetRunningContainers(namespace string, se
 		return nil
 	}
 
-	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		LabelSelector: labelSelector,
-	})
+	}))
 	if err != nil {
 		return nil
 	}
@@ -492,9 +494,9 @@ func (h *WorkloadHandler) fetchStatefulSetRunningContainers(namespace string, se
 	metricsMap := make(map[string]map[string]ContainerResource)
 	mc, err := h.k8s.GetMetricsClient()
 	if err == nil {
-		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), metav1.ListOptions{
+		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 			LabelSelector: labelSelector,
-		})
+		}))
 		if err == nil {
 			for _, pm := range podMetrics.Items {
 				if metricsMap[pm.Name] == nil {
@@ -571,9 +573,9 @@ func (h *WorkloadHandler) StatefulSetEvents(ctx *gofr.Context) (interface{}, err
 	}
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=StatefulSet", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -649,14 +651,14 @@ type ReplicaSetRunningContainer struct {
 }
 
 func (h *WorkloadHandler) ListReplicaSets(ctx *gofr.Context) (interface{}, error) {
-	namespace := ctx.Param("namespace")
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
 
 	client, err := h.k8s.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	replicasets, err := client.AppsV1().ReplicaSets(namespace).List(context.Background(), metav1.ListOptions{})
+	replicasets, err := client.AppsV1().ReplicaSets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
 	if err != nil {
 		return nil, err
 	}
@@ -693,7 +695,7 @@ func (h *WorkloadHandler) GetReplicaSet(ctx *gofr.Context) (interface{}, error)
 
 	rs, err := client.AppsV1().ReplicaSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return lookupError(err)
 	}
 
 	desired := int32(0)
@@ -769,9 +771,9 @@ func (h *WorkloadHandler) fetchReplicaSetRunningContainers(namespace string, sel
 		return nil
 	}
 
-	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+	pods, err := client.CoreV1().Pods(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		LabelSelector: labelSelector,
-	})
+	}))
 	if err != nil {
 		return nil
 	}
@@ -780,9 +782,9 @@ func (h *WorkloadHandler) fetchReplicaSetRunningContainers(namespace string, sel
 	metricsMap := make(map[string]map[string]ContainerResource)
 	mc, err := h.k8s.GetMetricsClient()
 	if err == nil {
-		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), metav1.ListOptions{
+		podMetrics, err := mc.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 			LabelSelector: labelSelector,
-		})
+		}))
 		if err == nil {
 			for _, pm := range podMetrics.Items {
 				if metricsMap[pm.Name] == nil {
@@ -859,9 +861,9 @@ func (h *WorkloadHandler) ReplicaSetEvents(ctx *gofr.Context) (interface{}, erro
 	}
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=ReplicaSet", name, namespace)
-	events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	events, err := client.CoreV1().Events(namespace).List(context.Background(), listOptions(metav1.ListOptions{
 		FieldSelector: fieldSelector,
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -945,3 +947,90 @@ func (h *WorkloadHandler) DeleteReplicaSet(ctx *gofr.Context) (interface{}, erro
 
 	return map[string]string{"message": fmt.Sprintf("ReplicaSet %s deleted", name)}, nil
 }
+
+// ImageWorkload is a workload with a container running a matched image
+type ImageWorkload struct {
+	Kind       string   `json:"kind"`
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace"`
+	Containers []string `json:"containers"`
+	Images     []string `json:"images"`
+}
+
+// ListByImage returns the deployments, statefulsets and daemonsets whose
+// containers (including init containers) use the image. With anyTag=true the
+// tag or digest is ignored, so image=repo/name matches repo/name:1.2.
+func (h *WorkloadHandler) ListByImage(ctx *gofr.Context) (interface{}, error) {
+	image := ctx.Param("image")
+	if image == "" {
+		return nil, errors.New("image is required")
+	}
+	anyTag := ctx.Param("anyTag") == "true"
+	namespace := resolveNamespace(ctx, h.k8s).Namespace
+
+	client, err := h.k8s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []ImageWorkload{}
+	add := func(kind string, meta metav1.Object, spec corev1.PodSpec) {
+		workload := ImageWorkload{Kind: kind, Name: meta.GetName(), Namespace: meta.GetNamespace()}
+		containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+		for _, c := range containers {
+			if imageMatches(c.Image, image, anyTag) {
+				workload.Containers = append(workload.Containers, c.Name)
+				workload.Images = append(workload.Images, c.Image)
+			}
+		}
+		if len(workload.Containers) > 0 {
+			result = append(result, workload)
+		}
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range deployments.Items {
+		add("Deployment", &deployments.Items[i], deployments.Items[i].Spec.Template.Spec)
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range statefulSets.Items {
+		add("StatefulSet", &statefulSets.Items[i], statefulSets.Items[i].Spec.Template.Spec)
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(context.Background(), listOptionsFor(ctx, metav1.ListOptions{}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range daemonSets.Items {
+		add("DaemonSet", &daemonSets.Items[i], daemonSets.Items[i].Spec.Template.Spec)
+	}
+
+	return result, nil
+}
+
+// imageMatches compares a container image with the requested one, optionally ignoring tag and digest
+func imageMatches(containerImage, image string, anyTag bool) bool {
+	if !anyTag {
+		return containerImage == image
+	}
+	return imageRepository(containerImage) == imageRepository(image)
+}
+
+// imageRepository strips the tag and digest from an image reference. A colon
+// before the last slash is a registry port, not a tag.
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
@@ -10,7 +10,6 @@ import (
 
 	"gofr.dev/pkg/gofr"
 	appsv1 "k8s.io/api/apps/v1"
-	authv1 "k8s.io/api/authorization/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -72,6 +71,12 @@ type YAMLResponse struct {
 	CanEdit bool   `json:"canEdit"`
 }
 
+// JSONResponse is the format=json variant of YAMLResponse
+type JSONResponse struct {
+	JSON    string `json:"json"`
+	CanEdit bool   `json:"canEdit"`
+}
+
 // Get returns the YAML representation of a Kubernetes resource
 func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	resourceType := ctx.PathParam("type")
@@ -94,7 +99,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "pods":
 		pod, e := client.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		pod.APIVersion = meta.apiVersion
 		pod.Kind = meta.kind
@@ -102,7 +107,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "deployments":
 		deploy, e := client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		deploy.APIVersion = meta.apiVersion
 		deploy.Kind = meta.kind
@@ -110,7 +115,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "services":
 		svc, e := client.CoreV1().Services(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		svc.APIVersion = meta.apiVersion
 		svc.Kind = meta.kind
@@ -118,7 +123,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "configmaps":
 		cm, e := client.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		cm.APIVersion = meta.apiVersion
 		cm.Kind = meta.kind
@@ -126,21 +131,17 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "secrets":
 		secret, e := client.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		secret.APIVersion = meta.apiVersion
 		secret.Kind = meta.kind
 		// Secrets need special ordering (type before data)
-		yamlStr, marshalErr := h.marshalWithOrder(secret, []string{"apiVersion", "kind", "metadata", "type", "immutable"}, []string{"stringData", "data"})
-		if marshalErr != nil {
-			return nil, marshalErr
-		}
 		canEdit := h.checkUpdatePermission(client, meta, namespace, name)
-		return YAMLResponse{YAML: yamlStr, CanEdit: canEdit}, nil
+		return h.formatResponse(ctx, secret, []string{"apiVersion", "kind", "metadata", "type", "immutable"}, []string{"stringData", "data"}, canEdit)
 	case "jobs":
 		job, e := client.BatchV1().Jobs(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		job.APIVersion = meta.apiVersion
 		job.Kind = meta.kind
@@ -148,7 +149,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "cronjobs":
 		cj, e := client.BatchV1().CronJobs(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		cj.APIVersion = meta.apiVersion
 		cj.Kind = meta.kind
@@ -156,7 +157,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "pvcs":
 		pvc, e := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		pvc.APIVersion = meta.apiVersion
 		pvc.Kind = meta.kind
@@ -164,7 +165,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "statefulsets":
 		ss, e := client.AppsV1().StatefulSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		ss.APIVersion = meta.apiVersion
 		ss.Kind = meta.kind
@@ -172,7 +173,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "daemonsets":
 		ds, e := client.AppsV1().DaemonSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		ds.APIVersion = meta.apiVersion
 		ds.Kind = meta.kind
@@ -180,7 +181,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "replicasets":
 		rs, e := client.AppsV1().ReplicaSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		rs.APIVersion = meta.apiVersion
 		rs.Kind = meta.kind
@@ -188,7 +189,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "ingresses":
 		ing, e := client.NetworkingV1().Ingresses(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		ing.APIVersion = meta.apiVersion
 		ing.Kind = meta.kind
@@ -196,7 +197,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "endpoints":
 		ep, e := client.CoreV1().Endpoints(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		ep.APIVersion = meta.apiVersion
 		ep.Kind = meta.kind
@@ -204,7 +205,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "networkpolicies":
 		np, e := client.NetworkingV1().NetworkPolicies(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		np.APIVersion = meta.apiVersion
 		np.Kind = meta.kind
@@ -212,7 +213,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "hpas":
 		hpa, e := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		hpa.APIVersion = meta.apiVersion
 		hpa.Kind = meta.kind
@@ -220,7 +221,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "events":
 		event, e := client.CoreV1().Events(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		event.APIVersion = meta.apiVersion
 		event.Kind = meta.kind
@@ -228,7 +229,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "serviceaccounts":
 		sa, e := client.CoreV1().ServiceAccounts(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		sa.APIVersion = meta.apiVersion
 		sa.Kind = meta.kind
@@ -236,7 +237,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "resourcequotas":
 		rq, e := client.CoreV1().ResourceQuotas(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		rq.APIVersion = meta.apiVersion
 		rq.Kind = meta.kind
@@ -244,7 +245,7 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	case "limitranges":
 		lr, e := client.CoreV1().LimitRanges(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		lr.APIVersion = meta.apiVersion
 		lr.Kind = meta.kind
@@ -254,13 +255,8 @@ func (h *YAMLHandler) Get(ctx *gofr.Context) (interface{}, error) {
 	}
 
 	// Standard ordering for most resources
-	yamlStr, marshalErr := h.marshalWithOrder(obj, []string{"apiVersion", "kind", "metadata", "spec"}, []string{"status"})
-	if marshalErr != nil {
-		return nil, marshalErr
-	}
-
 	canEdit := h.checkUpdatePermission(client, meta, namespace, name)
-	return YAMLResponse{YAML: yamlStr, CanEdit: canEdit}, nil
+	return h.formatResponse(ctx, obj, []string{"apiVersion", "kind", "metadata", "spec"}, []string{"status"}, canEdit)
 }
 
 // GetClusterScoped returns YAML for cluster-scoped resources
@@ -284,7 +280,7 @@ func (h *YAMLHandler) GetClusterScoped(ctx *gofr.Context) (interface{}, error) {
 	case "pvs":
 		pv, e := client.CoreV1().PersistentVolumes().Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		pv.APIVersion = meta.apiVersion
 		pv.Kind = meta.kind
@@ -292,7 +288,7 @@ func (h *YAMLHandler) GetClusterScoped(ctx *gofr.Context) (interface{}, error) {
 	case "namespaces":
 		ns, e := client.CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		ns.APIVersion = meta.apiVersion
 		ns.Kind = meta.kind
@@ -300,7 +296,7 @@ func (h *YAMLHandler) GetClusterScoped(ctx *gofr.Context) (interface{}, error) {
 	case "nodes":
 		node, e := client.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		node.APIVersion = meta.apiVersion
 		node.Kind = meta.kind
@@ -308,7 +304,7 @@ func (h *YAMLHandler) GetClusterScoped(ctx *gofr.Context) (interface{}, error) {
 	case "storageclasses":
 		sc, e := client.StorageV1().StorageClasses().Get(context.Background(), name, metav1.GetOptions{})
 		if e != nil {
-			return nil, e
+			return lookupError(e)
 		}
 		sc.APIVersion = meta.apiVersion
 		sc.Kind = meta.kind
@@ -317,13 +313,33 @@ func (h *YAMLHandler) GetClusterScoped(ctx *gofr.Context) (interface{}, error) {
 		return nil, errInvalidResourceType
 	}
 
-	yamlStr, marshalErr := h.marshalWithOrder(obj, []string{"apiVersion", "kind", "metadata", "spec"}, []string{"status"})
-	if marshalErr != nil {
-		return nil, marshalErr
-	}
-
 	canEdit := h.checkUpdatePermission(client, meta, "", name)
-	return YAMLResponse{YAML: yamlStr, CanEdit: canEdit}, nil
+	return h.formatResponse(ctx, obj, []string{"apiVersion", "kind", "metadata", "spec"}, []string{"status"}, canEdit)
+}
+
+// formatResponse renders obj as ordered YAML, or with ?format=json as indented
+// JSON for scripts. The JSON drops managedFields, which is only noise to tools
+// consuming the object; the YAML keeps them since it round-trips through Update.
+func (h *YAMLHandler) formatResponse(ctx *gofr.Context, obj interface{}, topKeys, bottomKeys []string, canEdit bool) (interface{}, error) {
+	switch ctx.Param("format") {
+	case "", "yaml":
+		yamlStr, err := h.marshalWithOrder(obj, topKeys, bottomKeys)
+		if err != nil {
+			return nil, err
+		}
+		return YAMLResponse{YAML: yamlStr, CanEdit: canEdit}, nil
+	case "json":
+		if o, ok := obj.(metav1.Object); ok {
+			o.SetManagedFields(nil)
+		}
+		jsonBytes, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return JSONResponse{JSON: string(jsonBytes), CanEdit: canEdit}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q: use yaml or json", ctx.Param("format"))
+	}
 }
 
 // marshalWithOrder marshals an object with specific field ordering
@@ -585,31 +601,9 @@ func (h *YAMLHandler) applyResource(client *kubernetes.Clientset, resourceType,
 
 // checkUpdatePermission checks if the current user can update the resource
 func (h *YAMLHandler) checkUpdatePermission(client interface{}, meta resourceMeta, namespace, name string) bool {
-	k8sClient, ok := h.k8s.GetClientset()
-	if !ok {
-		return false
-	}
-
-	sar := &authv1.SelfSubjectAccessReview{
-		Spec: authv1.SelfSubjectAccessReviewSpec{
-			ResourceAttributes: &authv1.ResourceAttributes{
-				Namespace: namespace,
-				Verb:      "update",
-				Group:     meta.group,
-				Resource:  meta.resource,
-				Name:      name,
-			},
-		},
-	}
-
-	result, err := k8sClient.AuthorizationV1().SelfSubjectAccessReviews().Create(
-		context.Background(),
-		sar,
-		metav1.CreateOptions{},
-	)
+	allowed, err := h.k8s.CheckAccess("update", meta.group, meta.resource, namespace, name)
 	if err != nil {
 		return false
 	}
-
-	return result.Status.Allowed
+	return allowed
 }
@@ -1,11 +1,16 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sync"
+	"time"
 
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -21,6 +26,48 @@ type K8sManager struct {
 	clients        map[string]*kubernetes.Clientset
 	metricsClients map[string]*metricsv.Clientset
 	mu             sync.RWMutex
+
+	accessCache map[accessKey]accessEntry
+	accessMu    sync.Mutex
+
+	reachability   map[string]reachabilityEntry
+	reachabilityMu sync.Mutex
+
+	// selectedNamespace is the namespace chosen in the UI for this session; reset on context switch
+	selectedNamespace string
+
+	// requestTimeout caps every API call made through clients from GetClient and
+	// GetConfig. Streams use clients from GetStreamingClient, which have no cap.
+	requestTimeout   time.Duration
+	streamingClients map[string]*kubernetes.Clientset
+
+	// The kubeconfig is re-read when it changes if reloadInterval is set
+	reloadInterval  time.Duration
+	lastReloadCheck time.Time
+	configModTime   time.Time
+	reloadMu        sync.Mutex
+}
+
+// accessReviewTTL is how long a SelfSubjectAccessReview result is reused
+const accessReviewTTL = time.Minute
+
+// maxAccessCacheEntries caps the access review cache
+const maxAccessCacheEntries = 4096
+
+// accessKey identifies a cached access review. The context is part of the key
+// so switching clusters never reuses another cluster's answer.
+type accessKey struct {
+	context   string
+	verb      string
+	group     string
+	resource  string
+	namespace string
+	name      string
+}
+
+type accessEntry struct {
+	allowed bool
+	expires time.Time
 }
 
 // ClusterInfo represents a Kubernetes cluster context
@@ -31,9 +78,12 @@ type ClusterInfo struct {
 	IsCurrent bool   `json:"isCurrent"`
 }
 
-// NewK8sManager creates a new Kubernetes client manager
-func NewK8sManager() (*K8sManager, error) {
-	kubeconfig := os.Getenv("KUBECONFIG")
+// NewK8sManager creates a new Kubernetes client manager. An explicit kubeconfig
+// path takes precedence over KUBECONFIG and ~/.kube/config.
+func NewK8sManager(kubeconfig string) (*K8sManager, error) {
+	if kubeconfig == "" {
+		kubeconfig = os.Getenv("KUBECONFIG")
+	}
 	if kubeconfig == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -47,17 +97,116 @@ func NewK8sManager() (*K8sManager, error) {
 		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
-	return &K8sManager{
+	m := &K8sManager{
 		kubeconfig:     kubeconfig,
 		config:         config,
 		currentContext: config.CurrentContext,
 		clients:        make(map[string]*kubernetes.Clientset),
 		metricsClients: make(map[string]*metricsv.Clientset),
-	}, nil
+		accessCache:    make(map[accessKey]accessEntry),
+		reachability:   make(map[string]reachabilityEntry),
+
+		streamingClients: make(map[string]*kubernetes.Clientset),
+	}
+	if info, err := os.Stat(kubeconfig); err == nil {
+		m.configModTime = info.ModTime()
+	}
+	return m, nil
+}
+
+// SetRequestTimeout sets a hard ceiling on every non-streaming API call. Zero
+// disables it. It must be called before serving requests.
+func (m *K8sManager) SetRequestTimeout(timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requestTimeout = timeout
+}
+
+// SetConfigReloadInterval makes context lookups re-read the kubeconfig when it
+// has changed on disk, checking at most once per interval. Zero disables it.
+func (m *K8sManager) SetConfigReloadInterval(interval time.Duration) {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+	m.reloadInterval = interval
+}
+
+// reloadIfChanged re-reads the kubeconfig if the reload interval has passed
+// and the file was modified since it was last loaded
+func (m *K8sManager) reloadIfChanged() {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	if m.reloadInterval <= 0 || time.Since(m.lastReloadCheck) < m.reloadInterval {
+		return
+	}
+	m.lastReloadCheck = time.Now()
+
+	info, err := os.Stat(m.kubeconfig)
+	if err != nil || info.ModTime().Equal(m.configModTime) {
+		return
+	}
+	config, err := clientcmd.LoadFromFile(m.kubeconfig)
+	if err != nil {
+		// Tools may be midway through rewriting the file; keep the old config and retry next time
+		return
+	}
+	m.configModTime = info.ModTime()
+	m.applyConfig(config)
+}
+
+// applyConfig swaps in a reloaded kubeconfig. Cached clients and results are
+// dropped only for contexts whose context, cluster or user entry changed, so
+// unrelated edits don't reconnect everything. If the current context was
+// removed, the file's current-context is used instead.
+func (m *K8sManager) applyConfig(config *api.Config) {
+	m.mu.Lock()
+	changed := make(map[string]bool)
+	for name := range m.config.Contexts {
+		if !sameContext(m.config, config, name) {
+			changed[name] = true
+			delete(m.clients, name)
+			delete(m.metricsClients, name)
+			delete(m.streamingClients, name)
+		}
+	}
+	if _, exists := config.Contexts[m.currentContext]; !exists {
+		m.currentContext = config.CurrentContext
+		m.selectedNamespace = ""
+	}
+	m.config = config
+	m.mu.Unlock()
+
+	m.accessMu.Lock()
+	for key := range m.accessCache {
+		if changed[key.context] {
+			delete(m.accessCache, key)
+		}
+	}
+	m.accessMu.Unlock()
+
+	m.reachabilityMu.Lock()
+	for name := range changed {
+		delete(m.reachability, name)
+	}
+	m.reachabilityMu.Unlock()
+}
+
+// sameContext reports whether a context resolves to the same cluster and credentials in both configs
+func sameContext(old, new *api.Config, name string) bool {
+	oldCtx, inOld := old.Contexts[name]
+	newCtx, inNew := new.Contexts[name]
+	if !inOld || !inNew {
+		return false
+	}
+	return reflect.DeepEqual(oldCtx, newCtx) &&
+		reflect.DeepEqual(old.Clusters[oldCtx.Cluster], new.Clusters[newCtx.Cluster]) &&
+		reflect.DeepEqual(old.AuthInfos[oldCtx.AuthInfo], new.AuthInfos[newCtx.AuthInfo])
 }
 
 // ListContexts returns all available contexts from kubeconfig
 func (m *K8sManager) ListContexts() []ClusterInfo {
+	m.reloadIfChanged()
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -82,12 +231,16 @@ func (m *K8sManager) CurrentContext() string {
 
 // SwitchContext switches to a different context and pre-warms the client
 func (m *K8sManager) SwitchContext(contextName string) error {
+	// A context added since the last list can be switched to right away
+	m.reloadIfChanged()
+
 	m.mu.Lock()
 	if _, exists := m.config.Contexts[contextName]; !exists {
 		m.mu.Unlock()
 		return fmt.Errorf("context %q not found", contextName)
 	}
 	m.currentContext = contextName
+	m.selectedNamespace = ""
 	m.mu.Unlock()
 
 	// Pre-warm the client synchronously so subsequent calls are fast
@@ -130,7 +283,47 @@ func (m *K8sManager) GetClient() (*kubernetes.Clientset, error) {
 	return client, nil
 }
 
-// buildConfig creates a rest.Config for the specified context
+// GetStreamingClient returns a clientset for the current context without the
+// request timeout, for watches and followed logs that stay open indefinitely
+func (m *K8sManager) GetStreamingClient() (*kubernetes.Clientset, error) {
+	m.mu.RLock()
+	context := m.currentContext
+	client, exists := m.streamingClients[context]
+	timeout := m.requestTimeout
+	m.mu.RUnlock()
+
+	if exists {
+		return client, nil
+	}
+	// Without a timeout the regular client already suits streams
+	if timeout == 0 {
+		return m.GetClient()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if client, exists = m.streamingClients[context]; exists {
+		return client, nil
+	}
+
+	restConfig, err := m.buildConfig(context)
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = 0
+
+	client, err = kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for context %q: %w", context, err)
+	}
+
+	m.streamingClients[context] = client
+	return client, nil
+}
+
+// buildConfig creates a rest.Config for the specified context. The request
+// timeout is only set at startup, so it's read without locking.
 func (m *K8sManager) buildConfig(contextName string) (*rest.Config, error) {
 	configOverrides := &clientcmd.ConfigOverrides{
 		CurrentContext: contextName,
@@ -141,7 +334,12 @@ func (m *K8sManager) buildConfig(contextName string) (*rest.Config, error) {
 		configOverrides,
 	)
 
-	return clientConfig.ClientConfig()
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = m.requestTimeout
+	return restConfig, nil
 }
 
 // GetDefaultNamespace returns the default namespace for the current context
@@ -155,6 +353,20 @@ func (m *K8sManager) GetDefaultNamespace() string {
 	return "default"
 }
 
+// SelectedNamespace returns the namespace selected for this session, or "" if none
+func (m *K8sManager) SelectedNamespace() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.selectedNamespace
+}
+
+// SetSelectedNamespace sets the session namespace used when requests don't name one
+func (m *K8sManager) SetSelectedNamespace(namespace string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.selectedNamespace = namespace
+}
+
 // GetConfig returns the rest.Config for the current context
 func (m *K8sManager) GetConfig() (*rest.Config, error) {
 	m.mu.RLock()
@@ -206,3 +418,247 @@ func (m *K8sManager) GetMetricsClient() (*metricsv.Clientset, error) {
 	m.metricsClients[context] = client
 	return client, nil
 }
+
+// CheckAccess reports whether the current user may perform verb on the
+// resource, using a SelfSubjectAccessReview. Results are cached briefly since
+// permissions rarely change within a session; failed reviews are not cached.
+func (m *K8sManager) CheckAccess(verb, group, resource, namespace, name string) (bool, error) {
+	key := accessKey{
+		context:   m.CurrentContext(),
+		verb:      verb,
+		group:     group,
+		resource:  resource,
+		namespace: namespace,
+		name:      name,
+	}
+
+	m.accessMu.Lock()
+	entry, exists := m.accessCache[key]
+	if exists && !time.Now().Before(entry.expires) {
+		delete(m.accessCache, key)
+		exists = false
+	}
+	m.accessMu.Unlock()
+
+	if exists {
+		return entry.allowed, nil
+	}
+
+	client, err := m.GetClient()
+	if err != nil {
+		return false, err
+	}
+
+	sar := &authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     group,
+				Resource:  resource,
+				Name:      name,
+			},
+		},
+	}
+
+	result, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.Background(), sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	m.accessMu.Lock()
+	// Keys go down to object names, so sweep expired entries once the cache is large
+	if len(m.accessCache) >= maxAccessCacheEntries {
+		now := time.Now()
+		for k, e := range m.accessCache {
+			if !now.Before(e.expires) {
+				delete(m.accessCache, k)
+			}
+		}
+		// Everything is still fresh; start over rather than grow without bound
+		if len(m.accessCache) >= maxAccessCacheEntries {
+			clear(m.accessCache)
+		}
+	}
+	m.accessCache[key] = accessEntry{allowed: result.Status.Allowed, expires: time.Now().Add(accessReviewTTL)}
+	m.accessMu.Unlock()
+
+	return result.Status.Allowed, nil
+}
+
+// reachabilityTTL is how long a context reachability probe result is reused
+const reachabilityTTL = 30 * time.Second
+
+// reachabilityTimeout bounds a single reachability probe
+const reachabilityTimeout = 3 * time.Second
+
+// scanReachabilityTTL is how long results from ScanReachability are reused.
+// It's longer than reachabilityTTL so a startup scan is still cached when the
+// UI is first opened.
+const scanReachabilityTTL = 5 * time.Minute
+
+// scanConcurrency bounds how many contexts ScanReachability probes at once
+const scanConcurrency = 8
+
+// ContextDetails describes a context's cluster endpoint and credentials, read
+// from the kubeconfig without connecting
+type ContextDetails struct {
+	ClusterInfo
+	Server     string `json:"server,omitempty"`
+	AuthInfo   string `json:"user,omitempty"`
+	AuthMethod string `json:"authMethod"` // token, client-certificate, exec, auth-provider, basic or none
+	// ExecCommand is the credential plugin run for exec auth; it may prompt or open a browser
+	ExecCommand         string `json:"execCommand,omitempty"`
+	ExecInteractiveMode string `json:"execInteractiveMode,omitempty"`
+}
+
+// Reachability is the result of probing a context's API server
+type Reachability struct {
+	Reachable bool   `json:"reachable"`
+	Version   string `json:"version,omitempty"`
+	Error     string `json:"error,omitempty"`
+	CheckedAt string `json:"checkedAt"`
+}
+
+type reachabilityEntry struct {
+	result  Reachability
+	expires time.Time
+}
+
+// DescribeContexts returns every kubeconfig context with its server and auth method
+func (m *K8sManager) DescribeContexts() []ContextDetails {
+	m.reloadIfChanged()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var contexts []ContextDetails
+	for name, ctx := range m.config.Contexts {
+		details := ContextDetails{
+			ClusterInfo: ClusterInfo{
+				Name:      name,
+				Cluster:   ctx.Cluster,
+				Namespace: ctx.Namespace,
+				IsCurrent: name == m.currentContext,
+			},
+			AuthInfo:   ctx.AuthInfo,
+			AuthMethod: "none",
+		}
+		if cluster, ok := m.config.Clusters[ctx.Cluster]; ok {
+			details.Server = cluster.Server
+		}
+
+		if auth, ok := m.config.AuthInfos[ctx.AuthInfo]; ok {
+			switch {
+			case auth.Exec != nil:
+				details.AuthMethod = "exec"
+				details.ExecCommand = auth.Exec.Command
+				details.ExecInteractiveMode = string(auth.Exec.InteractiveMode)
+			case auth.AuthProvider != nil:
+				details.AuthMethod = "auth-provider"
+			case auth.Token != "" || auth.TokenFile != "":
+				details.AuthMethod = "token"
+			case len(auth.ClientCertificateData) > 0 || auth.ClientCertificate != "":
+				details.AuthMethod = "client-certificate"
+			case auth.Username != "":
+				details.AuthMethod = "basic"
+			}
+		}
+		contexts = append(contexts, details)
+	}
+	return contexts
+}
+
+// CheckReachability probes a context's API server by fetching its version.
+// Results are cached briefly so listing contexts doesn't hammer every cluster.
+func (m *K8sManager) CheckReachability(contextName string) Reachability {
+	m.reachabilityMu.Lock()
+	entry, exists := m.reachability[contextName]
+	m.reachabilityMu.Unlock()
+
+	if exists && time.Now().Before(entry.expires) {
+		return entry.result
+	}
+
+	return m.refreshReachability(contextName, reachabilityTTL)
+}
+
+// ScanReachability probes every context up front so the cluster switcher can
+// show health without waiting on each cluster. At most scanConcurrency probes
+// run at once, each bounded by reachabilityTimeout, and results are cached for
+// scanReachabilityTTL. Contexts using
+// an exec credential plugin are skipped unless current, as the plugin may
+// prompt or open a browser. It returns how many contexts were probed and how
+// many were reachable.
+func (m *K8sManager) ScanReachability() (probed, reachable int) {
+	var names []string
+	for _, details := range m.DescribeContexts() {
+		if details.AuthMethod == "exec" && !details.IsCurrent {
+			continue
+		}
+		names = append(names, details.Name)
+	}
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		sem = make(chan struct{}, scanConcurrency)
+	)
+	for _, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result := m.refreshReachability(name, scanReachabilityTTL)
+			if result.Reachable {
+				mu.Lock()
+				reachable++
+				mu.Unlock()
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	return len(names), reachable
+}
+
+// refreshReachability probes a context and caches the result for ttl
+func (m *K8sManager) refreshReachability(contextName string, ttl time.Duration) Reachability {
+	result := Reachability{CheckedAt: time.Now().Format(time.RFC3339)}
+	version, err := m.probeServer(contextName)
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Reachable = true
+		result.Version = version
+	}
+
+	m.reachabilityMu.Lock()
+	m.reachability[contextName] = reachabilityEntry{result: result, expires: time.Now().Add(ttl)}
+	m.reachabilityMu.Unlock()
+
+	return result
+}
+
+// probeServer returns the API server version of a context, without switching to it
+func (m *K8sManager) probeServer(contextName string) (string, error) {
+	restConfig, err := m.buildConfig(contextName)
+	if err != nil {
+		return "", err
+	}
+	restConfig.Timeout = reachabilityTimeout
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return "", err
+	}
+	return version.GitVersion, nil
+}
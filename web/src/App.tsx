@@ -25,13 +25,14 @@ import { StorageClasses } from './pages/StorageClasses';
 import { ServiceAccounts } from './pages/ServiceAccounts';
 import { Namespaces } from './pages/Namespaces';
 import { Quotas } from './pages/Quotas';
-import { api } from './services/api';
+import { api, ResourceLookupError } from './services/api';
 import { useDocumentTitle } from './hooks/useDocumentTitle';
 
 const queryClient = new QueryClient({
   defaultOptions: {
     queries: {
-      retry: 1,
+      // A missing or forbidden resource won't change on retry
+      retry: (failureCount, error) => !(error instanceof ResourceLookupError) && failureCount < 1,
       staleTime: 5000,
     },
   },
@@ -9,6 +9,7 @@ interface ContainerResourceData {
 interface ContainerCardProps {
   name: string;
   image?: string;
+  imageID?: string; // Running digest, shown on hover
   ready: boolean;
   state: string;
   restarts: number;
@@ -19,6 +20,7 @@ interface ContainerCardProps {
 export function ContainerCard({
   name,
   image,
+  imageID,
   ready,
   state,
   restarts,
@@ -37,7 +39,7 @@ export function ContainerCard({
           }`} />
           <span className="font-medium text-xs text-gray-800 shrink-0">{name}</span>
           {image && (
-            <span className="text-[10px] text-gray-400 font-mono truncate" title={image}>
+            <span className="text-[10px] text-gray-400 font-mono truncate" title={imageID ? `${image}\n${imageID}` : image}>
               {image}
             </span>
           )}
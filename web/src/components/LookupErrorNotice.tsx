@@ -0,0 +1,33 @@
+import { SearchX, ShieldAlert } from 'lucide-react';
+import { ResourceLookupError } from '../services/api';
+
+interface LookupErrorNoticeProps {
+  error: unknown;
+}
+
+// Explains why a detail panel has no data: the resource is gone or not readable
+export function LookupErrorNotice({ error }: LookupErrorNoticeProps) {
+  if (!(error instanceof ResourceLookupError)) return null;
+
+  if (error.forbidden) {
+    return (
+      <div className="flex items-start gap-2 bg-yellow-50 border border-yellow-200 rounded p-3 text-sm text-yellow-800">
+        <ShieldAlert className="w-4 h-4 mt-0.5 flex-shrink-0" />
+        <div>
+          <span className="font-medium">Forbidden:</span> your credentials can't read this resource.
+          <div className="text-xs mt-1 break-all">{error.message}</div>
+        </div>
+      </div>
+    );
+  }
+
+  return (
+    <div className="flex items-start gap-2 bg-gray-50 border border-gray-200 rounded p-3 text-sm text-gray-700">
+      <SearchX className="w-4 h-4 mt-0.5 flex-shrink-0" />
+      <div>
+        <span className="font-medium">Not found:</span> this resource no longer exists.
+        <div className="text-xs mt-1 break-all">{error.message}</div>
+      </div>
+    </div>
+  );
+}
@@ -8,9 +8,16 @@ export interface EnvVar {
   valueFrom?: string;
 }
 
+export interface EnvConflict {
+  name: string;
+  winner: string;
+  overridden: string[];
+}
+
 export interface ContainerEnv {
   name: string;
   env: EnvVar[];
+  envConflicts?: EnvConflict[];
 }
 
 export interface Tab {
@@ -277,6 +284,17 @@ function EnvTable({ containers }: { containers: ContainerEnv[] }) {
                 {container.name}
               </div>
             )}
+            {container.envConflicts && container.envConflicts.length > 0 && (
+              <div className="bg-yellow-50 px-3 py-1.5 text-xs text-yellow-800 border-b border-yellow-200 space-y-0.5">
+                {container.envConflicts.map((conflict) => (
+                  <div key={conflict.name}>
+                    <span className="font-mono font-medium">{conflict.name}</span> is declared{' '}
+                    {conflict.overridden.length + 1} times; {conflict.winner} wins over{' '}
+                    {conflict.overridden.join(', ')}
+                  </div>
+                ))}
+              </div>
+            )}
             <table className="w-full text-xs">
               <tbody>
                 {groups.map((group, groupIdx) => {
@@ -1,5 +1,5 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
-import { X, Copy, Check, Edit2, Save, XCircle } from 'lucide-react';
+import { X, Copy, Check, Edit2, Save, XCircle, Download } from 'lucide-react';
 import { api } from '../services/api';
 import { useState, useEffect, useRef } from 'react';
 import { Prism as SyntaxHighlighter } from 'react-syntax-highlighter';
@@ -124,6 +124,23 @@ export function YamlModal({ resourceType, namespace, name, onClose }: YamlModalP
     }
   };
 
+  const handleDownloadJSON = async () => {
+    try {
+      const { json } = await api.yaml.getJSON(resourceType, namespace, name);
+      const blob = new Blob([json], { type: 'application/json' });
+      const url = URL.createObjectURL(blob);
+      const a = document.createElement('a');
+      a.href = url;
+      a.download = `${name}.json`;
+      document.body.appendChild(a);
+      a.click();
+      document.body.removeChild(a);
+      URL.revokeObjectURL(url);
+    } catch (err) {
+      setSaveError((err as Error).message);
+    }
+  };
+
   const handleEdit = () => {
     setIsEditing(true);
     setSaveError(null);
@@ -185,6 +202,14 @@ export function YamlModal({ resourceType, namespace, name, onClose }: YamlModalP
                   {copied ? <Check className="w-4 h-4 text-green-600" /> : <Copy className="w-4 h-4" />}
                   {copied ? 'Copied!' : 'Copy'}
                 </button>
+                <button
+                  onClick={handleDownloadJSON}
+                  className="flex items-center gap-1 px-3 py-1 text-sm bg-gray-100 hover:bg-gray-200 rounded"
+                  title="Download as JSON, without managedFields"
+                >
+                  <Download className="w-4 h-4" />
+                  JSON
+                </button>
               </>
             )}
             <button onClick={onClose} className="p-1 text-gray-500 hover:text-gray-700">
@@ -38,7 +38,9 @@ export function useSummary(namespace?: string, enabled = true) {
 
   const fetchSummary = useCallback(async () => {
     try {
-      const params = namespace ? `?namespace=${namespace}` : '';
+      // limit=0 skips the item lists the overview cards don't show, letting the
+      // server count resources that need no status through metadata-only lists
+      const params = `?namespace=${namespace || '*'}&limit=0`;
       const response = await fetch(`/api/summary${params}`);
       if (!response.ok) {
         throw new Error('Failed to fetch summary');
@@ -8,6 +8,7 @@ import { ActionMenu } from '../components/ActionMenu';
 import { useToast } from '../components/Toast';
 import { ConfirmDialog } from '../components/ConfirmDialog';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface ConfigMapsProps {
   namespace?: string;
@@ -23,7 +24,7 @@ function ConfigMapDetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: configmapDetails } = useQuery({
+  const { data: configmapDetails, error: detailsError } = useQuery({
     queryKey: ['configmap-details', configmap.namespace, configmap.name],
     queryFn: () => api.configmaps.get(configmap.namespace, configmap.name),
   });
@@ -57,6 +58,8 @@ function ConfigMapDetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
@@ -9,6 +9,7 @@ import { useToast } from '../components/Toast';
 import { ConfirmDialog } from '../components/ConfirmDialog';
 import { ContainerCard } from '../components/ContainerCard';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface DaemonSetsProps {
   namespace?: string;
@@ -33,7 +34,7 @@ function DaemonSetDetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: daemonsetDetails, isLoading: detailsLoading } = useQuery({
+  const { data: daemonsetDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['daemonset-details', daemonset.namespace, daemonset.name],
     queryFn: () => api.workloads.getDaemonSet(daemonset.namespace, daemonset.name),
   });
@@ -77,6 +78,8 @@ function DaemonSetDetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
@@ -1,7 +1,7 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
 import { api } from '../services/api';
 import type { DeploymentInfo } from '../services/api';
-import { RefreshCw, RotateCcw, Scale, FileCode, Trash2, X, ChevronRight, Info } from 'lucide-react';
+import { RefreshCw, RotateCcw, Scale, FileCode, Trash2, X, ChevronRight, Info, History, Undo2, Package } from 'lucide-react';
 import { useState } from 'react';
 import { YamlModal } from '../components/YamlModal';
 import { ActionMenu } from '../components/ActionMenu';
@@ -9,6 +9,7 @@ import { useToast } from '../components/Toast';
 import { ConfirmDialog } from '../components/ConfirmDialog';
 import { ContainerCard, PodContainersGroup } from '../components/ContainerCard';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface DeploymentsProps {
   namespace?: string;
@@ -74,6 +75,198 @@ function ScaleModal({
   );
 }
 
+function SetImageModal({
+  deployment,
+  onClose,
+}: {
+  deployment: DeploymentInfo;
+  onClose: () => void;
+}) {
+  const queryClient = useQueryClient();
+  const { addToast } = useToast();
+  const [container, setContainer] = useState('');
+  const [image, setImage] = useState('');
+
+  const { data: details } = useQuery({
+    queryKey: ['deployment-details', deployment.namespace, deployment.name],
+    queryFn: () => api.deployments.get(deployment.namespace, deployment.name),
+  });
+
+  const containers = details?.containerDetails || [];
+  const selected = containers.find((c) => c.name === container) || containers[0];
+
+  const setImageMutation = useMutation({
+    mutationFn: () =>
+      api.deployments.setImage(deployment.namespace, deployment.name, selected?.name || '', image.trim()),
+    onSuccess: (result) => {
+      queryClient.invalidateQueries({ queryKey: ['deployments'] });
+      queryClient.invalidateQueries({ queryKey: ['deployment-details', deployment.namespace, deployment.name] });
+      addToast(result.message, 'success');
+      onClose();
+    },
+    onError: (error: Error) => {
+      addToast(`Failed to set image: ${error.message}`, 'error');
+    },
+  });
+
+  return (
+    <div className="fixed inset-0 bg-black/50 flex items-center justify-center z-50">
+      <div className="bg-white rounded-lg w-[32rem] p-6">
+        <h2 className="text-lg font-semibold mb-4">
+          Set Image: {deployment.name}
+        </h2>
+        <div className="mb-4">
+          <label className="block text-sm text-gray-600 mb-1">Container</label>
+          <select
+            value={selected?.name || ''}
+            onChange={(e) => setContainer(e.target.value)}
+            className="w-full border border-gray-300 rounded px-3 py-2"
+          >
+            {containers.map((c) => (
+              <option key={c.name} value={c.name}>{c.name}</option>
+            ))}
+          </select>
+          {selected && (
+            <p className="mt-1 text-xs text-gray-500 font-mono break-all">Current: {selected.image}</p>
+          )}
+        </div>
+        <div className="mb-4">
+          <label className="block text-sm text-gray-600 mb-1">New image</label>
+          <input
+            type="text"
+            value={image}
+            onChange={(e) => setImage(e.target.value)}
+            placeholder={selected?.image}
+            className="w-full border border-gray-300 rounded px-3 py-2 font-mono text-sm"
+          />
+        </div>
+        <div className="flex justify-end gap-2">
+          <button
+            onClick={onClose}
+            className="px-4 py-2 text-gray-600 hover:bg-gray-100 rounded"
+          >
+            Cancel
+          </button>
+          <button
+            onClick={() => setImageMutation.mutate()}
+            disabled={setImageMutation.isPending || !selected || !image.trim() || image.trim() === selected.image}
+            className="px-4 py-2 bg-blue-600 text-white rounded hover:bg-blue-700 disabled:opacity-50"
+          >
+            {setImageMutation.isPending ? 'Updating...' : 'Update'}
+          </button>
+        </div>
+      </div>
+    </div>
+  );
+}
+
+function HistoryModal({
+  deployment,
+  onClose,
+}: {
+  deployment: DeploymentInfo;
+  onClose: () => void;
+}) {
+  const queryClient = useQueryClient();
+  const { addToast } = useToast();
+  const [rollbackTarget, setRollbackTarget] = useState<number | null>(null);
+
+  const { data: revisions, isLoading, error } = useQuery({
+    queryKey: ['deployment-history', deployment.namespace, deployment.name],
+    queryFn: () => api.deployments.history(deployment.namespace, deployment.name),
+  });
+
+  const rollbackMutation = useMutation({
+    mutationFn: (revision: number) => api.deployments.rollback(deployment.namespace, deployment.name, revision),
+    onSuccess: (result) => {
+      queryClient.invalidateQueries({ queryKey: ['deployments'] });
+      queryClient.invalidateQueries({ queryKey: ['deployment-history', deployment.namespace, deployment.name] });
+      addToast(result.message, 'success');
+    },
+    onError: (error: Error) => {
+      addToast(`Failed to roll back: ${error.message}`, 'error');
+    },
+  });
+
+  return (
+    <div className="fixed inset-0 bg-black/50 flex items-center justify-center z-50">
+      <div className="bg-white rounded-lg w-2/3 max-h-[80vh] flex flex-col">
+        <div className="flex justify-between items-center p-4 border-b">
+          <h2 className="text-lg font-semibold">Rollout History: {deployment.name}</h2>
+          <button onClick={onClose} className="p-1 text-gray-500 hover:text-gray-700">
+            <X className="w-5 h-5" />
+          </button>
+        </div>
+        <div className="flex-1 overflow-auto">
+          {isLoading ? (
+            <div className="p-4 text-gray-500">Loading history...</div>
+          ) : error ? (
+            <div className="p-4 text-red-500">Error: {(error as Error).message}</div>
+          ) : revisions && revisions.length > 0 ? (
+            <table className="w-full">
+              <thead className="bg-gray-50 border-b">
+                <tr>
+                  <th className="text-left px-4 py-2 text-sm font-medium text-gray-600">Revision</th>
+                  <th className="text-left px-4 py-2 text-sm font-medium text-gray-600">Images</th>
+                  <th className="text-left px-4 py-2 text-sm font-medium text-gray-600">Replicas</th>
+                  <th className="text-left px-4 py-2 text-sm font-medium text-gray-600">Age</th>
+                  <th className="text-right px-4 py-2 text-sm font-medium text-gray-600"></th>
+                </tr>
+              </thead>
+              <tbody className="divide-y divide-gray-100">
+                {revisions.map((rev) => (
+                  <tr key={rev.replicaSet} className={rev.current ? 'bg-emerald-50' : ''}>
+                    <td className="px-4 py-2 text-sm font-medium">
+                      {rev.revision}
+                      {rev.current && <span className="ml-2 text-xs text-emerald-700">current</span>}
+                    </td>
+                    <td className="px-4 py-2 text-sm font-mono text-gray-700">
+                      {rev.images.map((image) => (
+                        <div key={image}>{image}</div>
+                      ))}
+                      {rev.changeCause && <div className="text-xs text-gray-500 font-sans">{rev.changeCause}</div>}
+                    </td>
+                    <td className="px-4 py-2 text-sm">{rev.replicas}</td>
+                    <td className="px-4 py-2 text-sm text-gray-600" title={rev.created}>{rev.age}</td>
+                    <td className="px-4 py-2 text-right">
+                      {!rev.current && (
+                        <button
+                          onClick={() => setRollbackTarget(rev.revision)}
+                          className="flex items-center gap-1 ml-auto px-2 py-1 text-sm bg-gray-100 hover:bg-gray-200 rounded"
+                        >
+                          <Undo2 className="w-4 h-4" />
+                          Roll back
+                        </button>
+                      )}
+                    </td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          ) : (
+            <p className="p-4 text-gray-500 text-sm">No revisions found</p>
+          )}
+        </div>
+      </div>
+
+      <ConfirmDialog
+        isOpen={rollbackTarget !== null}
+        title="Roll Back Deployment"
+        message={`Roll back deployment "${deployment.name}" to revision ${rollbackTarget}? This will trigger a rolling update.`}
+        confirmLabel="Roll back"
+        variant="warning"
+        isLoading={rollbackMutation.isPending}
+        onConfirm={() => {
+          if (rollbackTarget !== null) {
+            rollbackMutation.mutate(rollbackTarget, { onSettled: () => setRollbackTarget(null) });
+          }
+        }}
+        onCancel={() => setRollbackTarget(null)}
+      />
+    </div>
+  );
+}
+
 function DeploymentDetailsPanel({
   deployment,
   onClose,
@@ -83,14 +276,16 @@ function DeploymentDetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: deploymentDetails, isLoading: detailsLoading } = useQuery({
+  const { data: deploymentDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['deployment-details', deployment.namespace, deployment.name],
     queryFn: () => api.deployments.get(deployment.namespace, deployment.name),
   });
 
+  const [includeChildren, setIncludeChildren] = useState(true);
+
   const { data: events, isLoading: eventsLoading } = useQuery({
-    queryKey: ['deployment-events', deployment.namespace, deployment.name],
-    queryFn: () => api.deployments.events(deployment.namespace, deployment.name),
+    queryKey: ['deployment-events', deployment.namespace, deployment.name, includeChildren],
+    queryFn: () => api.deployments.events(deployment.namespace, deployment.name, includeChildren),
   });
 
   const details = deploymentDetails || deployment;
@@ -117,6 +312,8 @@ function DeploymentDetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
@@ -194,9 +391,11 @@ function DeploymentDetailsPanel({
         {/* Tabbed Metadata Section */}
         <MetadataTabs
           tabs={[
-            { key: 'env', label: 'Environment', envData: details.containerDetails?.map(c => ({ name: c.name, env: c.env || [] })) },
+            { key: 'env', label: 'Environment', envData: details.containerDetails?.map(c => ({ name: c.name, env: c.env || [], envConflicts: c.envConflicts })) },
             { key: 'selector', label: 'Selector', data: details.selector },
             { key: 'labels', label: 'Labels', data: details.labels },
+            { key: 'templateLabels', label: 'Pod Labels', data: details.templateLabels },
+            { key: 'templateAnnotations', label: 'Pod Annotations', data: details.templateAnnotations },
           ]}
         />
 
@@ -229,7 +428,17 @@ function DeploymentDetailsPanel({
 
         {/* Events */}
         <div>
-          <h3 className="text-sm font-semibold text-gray-700 mb-2">Events</h3>
+          <div className="flex items-center justify-between mb-2">
+            <h3 className="text-sm font-semibold text-gray-700">Events</h3>
+            <label className="flex items-center gap-1 text-xs text-gray-600">
+              <input
+                type="checkbox"
+                checked={includeChildren}
+                onChange={(e) => setIncludeChildren(e.target.checked)}
+              />
+              Include ReplicaSets and pods
+            </label>
+          </div>
           {eventsLoading ? (
             <p className="text-gray-500 text-sm">Loading...</p>
           ) : events && events.length > 0 ? (
@@ -251,6 +460,9 @@ function DeploymentDetailsPanel({
                       <span className="text-xs text-gray-400">x{event.count}</span>
                     )}
                     <span className="text-xs text-gray-400">{event.age}</span>
+                    {event.object && (
+                      <span className="text-xs text-gray-500 font-mono">{event.object}</span>
+                    )}
                   </div>
                   <p className="text-sm text-gray-600">{event.message}</p>
                 </div>
@@ -271,6 +483,8 @@ export function Deployments({ namespace, isConnected = true }: DeploymentsProps)
   const [scaleDeployment, setScaleDeployment] = useState<DeploymentInfo | null>(null);
   const [yamlDeployment, setYamlDeployment] = useState<DeploymentInfo | null>(null);
   const [restartTarget, setRestartTarget] = useState<DeploymentInfo | null>(null);
+  const [historyDeployment, setHistoryDeployment] = useState<DeploymentInfo | null>(null);
+  const [imageDeployment, setImageDeployment] = useState<DeploymentInfo | null>(null);
   const [deleteTarget, setDeleteTarget] = useState<DeploymentInfo | null>(null);
   const [selectedDeployment, setSelectedDeployment] = useState<DeploymentInfo | null>(null);
 
@@ -379,6 +593,16 @@ export function Deployments({ namespace, isConnected = true }: DeploymentsProps)
                         icon: <RotateCcw className="w-4 h-4" />,
                         onClick: () => setRestartTarget(dep),
                       },
+                      {
+                        label: 'Set Image',
+                        icon: <Package className="w-4 h-4" />,
+                        onClick: () => setImageDeployment(dep),
+                      },
+                      {
+                        label: 'Rollout History',
+                        icon: <History className="w-4 h-4" />,
+                        onClick: () => setHistoryDeployment(dep),
+                      },
                       {
                         label: 'View YAML',
                         icon: <FileCode className="w-4 h-4" />,
@@ -409,6 +633,18 @@ export function Deployments({ namespace, isConnected = true }: DeploymentsProps)
           onClose={() => setScaleDeployment(null)}
         />
       )}
+      {imageDeployment && (
+        <SetImageModal
+          deployment={imageDeployment}
+          onClose={() => setImageDeployment(null)}
+        />
+      )}
+      {historyDeployment && (
+        <HistoryModal
+          deployment={historyDeployment}
+          onClose={() => setHistoryDeployment(null)}
+        />
+      )}
       {yamlDeployment && (
         <YamlModal
           resourceType="deployments"
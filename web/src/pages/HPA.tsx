@@ -6,6 +6,7 @@ import { useState } from 'react';
 import { YamlModal } from '../components/YamlModal';
 import { ActionMenu } from '../components/ActionMenu';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface HPAProps {
   namespace?: string;
@@ -21,7 +22,7 @@ function HPADetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: hpaDetails, isLoading: detailsLoading } = useQuery({
+  const { data: hpaDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['hpa-details', hpa.namespace, hpa.name],
     queryFn: () => api.hpas.get(hpa.namespace, hpa.name),
   });
@@ -58,6 +59,8 @@ function HPADetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-3 gap-4">
           <div className="bg-gray-50 p-3 rounded">
@@ -1,7 +1,7 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
 import { api } from '../services/api';
 import type { JobInfo, CronJobInfo } from '../services/api';
-import { RefreshCw, FileCode, Trash2, X, ChevronRight, Info } from 'lucide-react';
+import { RefreshCw, FileCode, Trash2, X, ChevronRight, Info, Play } from 'lucide-react';
 import { useState } from 'react';
 import { YamlModal } from '../components/YamlModal';
 import { ActionMenu } from '../components/ActionMenu';
@@ -9,6 +9,7 @@ import { useToast } from '../components/Toast';
 import { ConfirmDialog } from '../components/ConfirmDialog';
 import { ContainerCard } from '../components/ContainerCard';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface JobsProps {
   namespace?: string;
@@ -38,7 +39,7 @@ function JobDetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: jobDetails, isLoading: detailsLoading } = useQuery({
+  const { data: jobDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['job-details', job.namespace, job.name],
     queryFn: () => api.jobs.get(job.namespace, job.name),
   });
@@ -72,6 +73,8 @@ function JobDetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
@@ -243,7 +246,7 @@ function CronJobDetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: cronjobDetails, isLoading: detailsLoading } = useQuery({
+  const { data: cronjobDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['cronjob-details', cronjob.namespace, cronjob.name],
     queryFn: () => api.jobs.getCronJob(cronjob.namespace, cronjob.name),
   });
@@ -282,6 +285,8 @@ function CronJobDetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
@@ -463,6 +468,18 @@ export function Jobs({ namespace, isConnected = true }: JobsProps) {
     },
   });
 
+  const triggerCronJobMutation = useMutation({
+    mutationFn: ({ ns, name }: { ns: string; name: string }) => api.jobs.triggerCronJob(ns, name),
+    onSuccess: (result) => {
+      queryClient.invalidateQueries({ queryKey: ['jobs'] });
+      queryClient.invalidateQueries({ queryKey: ['cronjobs'] });
+      addToast(`Started job ${result.job}`, 'success');
+    },
+    onError: (error: Error) => {
+      addToast(`Failed to trigger: ${error.message}`, 'error');
+    },
+  });
+
   if (!isConnected) {
     return <div className="text-gray-500">Not connected to cluster</div>;
   }
@@ -615,6 +632,11 @@ export function Jobs({ namespace, isConnected = true }: JobsProps) {
                               icon: <Info className="w-4 h-4" />,
                               onClick: () => setSelectedCronJob(cj),
                             },
+                            {
+                              label: 'Run Now',
+                              icon: <Play className="w-4 h-4" />,
+                              onClick: () => triggerCronJobMutation.mutate({ ns: cj.namespace, name: cj.name }),
+                            },
                             {
                               label: 'View YAML',
                               icon: <FileCode className="w-4 h-4" />,
@@ -1,6 +1,6 @@
 import { useQuery, useQueryClient } from '@tanstack/react-query';
 import { api } from '../services/api';
-import type { NodeInfo, NodeResource } from '../services/api';
+import type { NodeInfo, NodeResource, NodeStorage } from '../services/api';
 import { useState } from 'react';
 import { RefreshCw, X, FileCode, ChevronRight } from 'lucide-react';
 import { YamlModal } from '../components/YamlModal';
@@ -106,6 +106,22 @@ function PodsBar({ res }: { res: NodeResource }) {
   );
 }
 
+function StorageBar({ storage }: { storage: NodeStorage }) {
+  const formatGB = (bytes: number) => (bytes / (1024 * 1024 * 1024)).toFixed(1);
+  return (
+    <div className="space-y-1">
+      <ResourceBar
+        label="Ephemeral Storage"
+        used={storage.requested}
+        total={storage.allocatable}
+        unit=""
+        formatValue={(v) => `${formatGB(v)} GB`}
+      />
+      <div className="text-xs text-gray-500">Capacity: {formatGB(storage.capacity)} GB</div>
+    </div>
+  );
+}
+
 interface NodesProps {
   isConnected?: boolean;
 }
@@ -207,6 +223,25 @@ function NodeDetailsPanel({ node, onClose, onViewYaml }: {
           </div>
         </div>
 
+        {/* Storage */}
+        {node.storage && (
+          <div>
+            <h3 className="text-sm font-semibold text-gray-700 mb-2">Storage</h3>
+            <div className="bg-gray-50 p-3 rounded space-y-2">
+              <StorageBar storage={node.storage} />
+              <div className="text-sm">
+                <span className="text-gray-500">Disk/Inode Pressure:</span>{' '}
+                <span className={node.storage.diskPressure ? 'text-red-600 font-medium' : 'text-green-600'}>
+                  {node.storage.diskPressure ? 'Yes' : 'No'}
+                </span>
+              </div>
+              {node.storage.pressureMessage && (
+                <p className="text-xs text-gray-500">{node.storage.pressureMessage}</p>
+              )}
+            </div>
+          </div>
+        )}
+
         {/* Conditions */}
         <div>
           <h3 className="text-sm font-semibold text-gray-700 mb-2">Conditions</h3>
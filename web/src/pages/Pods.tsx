@@ -1,7 +1,7 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
 import { api } from '../services/api';
 import type { PodInfo } from '../services/api';
-import { Trash2, RefreshCw, FileText, FileCode, X, ChevronRight, Info, Download, Play, Pause, Terminal, Plug } from 'lucide-react';
+import { Trash2, RefreshCw, FileText, FileCode, X, ChevronRight, Info, Download, Play, Pause, Terminal, Plug, Bug } from 'lucide-react';
 import { useState } from 'react';
 import { YamlModal } from '../components/YamlModal';
 import { ActionMenu } from '../components/ActionMenu';
@@ -11,6 +11,7 @@ import { TerminalModal } from '../components/TerminalModal';
 import { PortForwardModal } from '../components/PortForwardModal';
 import { ContainerCard } from '../components/ContainerCard';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface PodsProps {
   namespace?: string;
@@ -37,6 +38,8 @@ function LogModal({ pod, onClose }: { pod: PodInfo; onClose: () => void }) {
   const [selectedContainer, setSelectedContainer] = useState<string>('');
   const [tailLines, setTailLines] = useState<number>(500);
   const [follow, setFollow] = useState(false);
+  const [previous, setPrevious] = useState(false);
+  const [timestamps, setTimestamps] = useState(false);
 
   // Fetch pod details to get container list
   const { data: podDetails } = useQuery({
@@ -44,13 +47,21 @@ function LogModal({ pod, onClose }: { pod: PodInfo; onClose: () => void }) {
     queryFn: () => api.pods.get(pod.namespace, pod.name),
   });
 
-  const containers = podDetails?.containers || [];
+  // Init and debug containers are listed after the app containers, which stay the default
+  const containers = [
+    ...(podDetails?.containers || []).map((c) => ({ ...c, label: c.name })),
+    ...(podDetails?.initContainers || []).map((c) => ({ ...c, label: `${c.name} (init)` })),
+    ...(podDetails?.ephemeralContainers || []).map((c) => ({ ...c, label: `${c.name} (debug)` })),
+  ];
   const activeContainer = selectedContainer || containers[0]?.name || '';
-
-  const { data, isLoading, isFetching } = useQuery({
-    queryKey: ['pod-logs', pod.namespace, pod.name, activeContainer, tailLines],
-    queryFn: () => api.pods.logs(pod.namespace, pod.name, activeContainer || undefined, tailLines || undefined),
-    refetchInterval: follow ? 2000 : false,
+  const hasRestarted = (containers.find((c) => c.name === activeContainer)?.restartCount || 0) > 0;
+  const showPrevious = previous && hasRestarted;
+
+  const { data, isLoading, isFetching, error } = useQuery({
+    queryKey: ['pod-logs', pod.namespace, pod.name, activeContainer, tailLines, showPrevious, timestamps],
+    queryFn: () => api.pods.logs(pod.namespace, pod.name, activeContainer || undefined, tailLines || undefined, showPrevious, timestamps),
+    // A previous instance's logs never change
+    refetchInterval: follow && !showPrevious ? 2000 : false,
   });
 
   const handleDownload = () => {
@@ -59,7 +70,7 @@ function LogModal({ pod, onClose }: { pod: PodInfo; onClose: () => void }) {
     const url = URL.createObjectURL(blob);
     const a = document.createElement('a');
     a.href = url;
-    a.download = `${pod.name}${activeContainer ? `-${activeContainer}` : ''}.log`;
+    a.download = `${pod.name}${activeContainer ? `-${activeContainer}` : ''}${showPrevious ? '-previous' : ''}.log`;
     document.body.appendChild(a);
     a.click();
     document.body.removeChild(a);
@@ -93,7 +104,7 @@ function LogModal({ pod, onClose }: { pod: PodInfo; onClose: () => void }) {
                 className="px-2 py-1 text-sm border rounded bg-white"
               >
                 {containers.map((c) => (
-                  <option key={c.name} value={c.name}>{c.name}</option>
+                  <option key={c.name} value={c.name}>{c.label}</option>
                 ))}
               </select>
             </div>
@@ -120,10 +131,33 @@ function LogModal({ pod, onClose }: { pod: PodInfo; onClose: () => void }) {
             </select>
           </div>
 
+          {/* Previous instance, for containers that have restarted */}
+          {hasRestarted && (
+            <label className="flex items-center gap-1.5 text-sm text-gray-600">
+              <input
+                type="checkbox"
+                checked={previous}
+                onChange={(e) => setPrevious(e.target.checked)}
+              />
+              Previous instance
+            </label>
+          )}
+
+          {/* Timestamps */}
+          <label className="flex items-center gap-1.5 text-sm text-gray-600">
+            <input
+              type="checkbox"
+              checked={timestamps}
+              onChange={(e) => setTimestamps(e.target.checked)}
+            />
+            Timestamps
+          </label>
+
           {/* Follow toggle */}
           <button
             onClick={() => setFollow(!follow)}
-            className={`flex items-center gap-1.5 px-3 py-1.5 text-sm rounded ${
+            disabled={showPrevious}
+            className={`flex items-center gap-1.5 px-3 py-1.5 text-sm rounded disabled:opacity-50 disabled:cursor-not-allowed ${
               follow
                 ? 'bg-green-100 text-green-700 border border-green-300'
                 : 'bg-gray-100 text-gray-700 border border-gray-300'
@@ -153,6 +187,21 @@ function LogModal({ pod, onClose }: { pod: PodInfo; onClose: () => void }) {
         <div className="flex-1 overflow-auto p-4 bg-gray-900 text-gray-100 font-mono text-sm">
           {isLoading ? (
             <p className="text-gray-400">Loading logs...</p>
+          ) : error ? (
+            <p className="text-red-400">Error: {(error as Error).message}</p>
+          ) : timestamps && data?.logs ? (
+            // Each line starts with the kubelet's RFC3339Nano timestamp and a space
+            <div>
+              {data.logs.replace(/\n$/, '').split('\n').map((line, i) => {
+                const space = line.indexOf(' ');
+                return (
+                  <div key={i} className="flex">
+                    <span className="text-gray-500 shrink-0 mr-3 select-none">{space > 0 ? line.slice(0, space) : ''}</span>
+                    <span className="whitespace-pre-wrap break-all">{space > 0 ? line.slice(space + 1) : line}</span>
+                  </div>
+                );
+              })}
+            </div>
           ) : (
             <pre className="whitespace-pre-wrap">{data?.logs || 'No logs available'}</pre>
           )}
@@ -168,7 +217,7 @@ function PodDetailsPanel({ pod, onClose, onViewLogs, onViewYaml }: {
   onViewLogs: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: podDetails, isLoading: detailsLoading } = useQuery({
+  const { data: podDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['pod-details', pod.namespace, pod.name],
     queryFn: () => api.pods.get(pod.namespace, pod.name),
   });
@@ -207,6 +256,8 @@ function PodDetailsPanel({ pod, onClose, onViewLogs, onViewYaml }: {
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
@@ -235,6 +286,41 @@ function PodDetailsPanel({ pod, onClose, onViewLogs, onViewYaml }: {
           </div>
         </div>
 
+        {/* Conditions, with why the pod isn't Ready */}
+        {podDetails?.notReadyReason && (
+          <div className="bg-yellow-50 border border-yellow-200 rounded p-3 text-sm text-yellow-800">
+            <span className="font-medium">Not ready:</span> {podDetails.notReadyReason}
+          </div>
+        )}
+        {podDetails?.nodeHealth?.problems && podDetails.nodeHealth.problems.length > 0 && (
+          <div className="bg-yellow-50 border border-yellow-200 rounded p-3 text-sm text-yellow-800">
+            <span className="font-medium">Node {podDetails.nodeHealth.name}:</span>
+            <ul className="mt-1 list-disc list-inside">
+              {podDetails.nodeHealth.problems.map((p) => (
+                <li key={p}>{p}</li>
+              ))}
+            </ul>
+          </div>
+        )}
+        {podDetails?.conditions && podDetails.conditions.length > 0 && (
+          <div>
+            <h3 className="text-sm font-semibold text-gray-700 mb-2">Conditions</h3>
+            <div className="flex flex-wrap gap-2">
+              {podDetails.conditions.map((c) => (
+                <span
+                  key={c.type}
+                  title={c.message || c.reason}
+                  className={`px-2 py-1 rounded text-xs font-medium ${
+                    c.status === 'True' ? 'bg-emerald-100 text-emerald-700' : 'bg-yellow-100 text-yellow-700'
+                  }`}
+                >
+                  {c.type}
+                </span>
+              ))}
+            </div>
+          </div>
+        )}
+
         {/* Containers */}
         <div>
           <h3 className="text-sm font-semibold text-gray-700 mb-2">Containers</h3>
@@ -247,6 +333,7 @@ function PodDetailsPanel({ pod, onClose, onViewLogs, onViewYaml }: {
                   key={container.name}
                   name={container.name}
                   image={container.image}
+                  imageID={container.imageID}
                   ready={container.ready}
                   state={container.state}
                   restarts={container.restartCount}
@@ -265,7 +352,7 @@ function PodDetailsPanel({ pod, onClose, onViewLogs, onViewYaml }: {
         {/* Tabbed Metadata Section */}
         <MetadataTabs
           tabs={[
-            { key: 'env', label: 'Environment', envData: podDetails?.containers?.map(c => ({ name: c.name, env: c.env || [] })) },
+            { key: 'env', label: 'Environment', envData: podDetails?.containers?.map(c => ({ name: c.name, env: c.env || [], envConflicts: c.envConflicts })) },
             { key: 'labels', label: 'Labels', data: podDetails?.labels },
           ]}
         />
@@ -314,6 +401,7 @@ export function Pods({ namespace, isConnected = true }: PodsProps) {
   const [deleteTarget, setDeleteTarget] = useState<PodInfo | null>(null);
   const [terminalPod, setTerminalPod] = useState<PodInfo | null>(null);
   const [portForwardPod, setPortForwardPod] = useState<PodInfo | null>(null);
+  const [terminalContainer, setTerminalContainer] = useState<string | undefined>();
 
   const { data: pods, isLoading, error } = useQuery({
     queryKey: ['pods', namespace],
@@ -333,6 +421,19 @@ export function Pods({ namespace, isConnected = true }: PodsProps) {
     },
   });
 
+  // Targets the first container when known so its processes are visible from the debug shell
+  const debugMutation = useMutation({
+    mutationFn: (pod: PodInfo) => api.pods.debug(pod.namespace, pod.name, undefined, pod.containers?.[0]?.name),
+    onMutate: (pod) => addToast(`Starting debug container in ${pod.name}...`, 'info'),
+    onSuccess: (debug, pod) => {
+      setTerminalContainer(debug.name);
+      setTerminalPod(pod);
+    },
+    onError: (error: Error) => {
+      addToast(`Failed to start debug container: ${error.message}`, 'error');
+    },
+  });
+
   if (!isConnected) {
     return <div className="text-gray-500">Not connected to cluster</div>;
   }
@@ -404,7 +505,15 @@ export function Pods({ namespace, isConnected = true }: PodsProps) {
                       {
                         label: 'Shell',
                         icon: <Terminal className="w-4 h-4" />,
-                        onClick: () => setTerminalPod(pod),
+                        onClick: () => {
+                          setTerminalContainer(undefined);
+                          setTerminalPod(pod);
+                        },
+                      },
+                      {
+                        label: 'Debug Container',
+                        icon: <Bug className="w-4 h-4" />,
+                        onClick: () => debugMutation.mutate(pod),
                       },
                       {
                         label: 'Port Forward',
@@ -484,6 +593,7 @@ export function Pods({ namespace, isConnected = true }: PodsProps) {
         <TerminalModal
           namespace={terminalPod.namespace}
           podName={terminalPod.name}
+          containerName={terminalContainer}
           onClose={() => setTerminalPod(null)}
         />
       )}
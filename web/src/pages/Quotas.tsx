@@ -81,6 +81,26 @@ export function Quotas({ namespace, isConnected = true }: QuotasProps) {
                 ))}
               </div>
             )},
+            { key: 'scopes', header: 'Scope', render: (item) => {
+              const scopes = [
+                ...(item.scopes || []),
+                ...(item.scopeSelector || []).map((s) =>
+                  s.values?.length ? `${s.scopeName} ${s.operator} ${s.values.join(', ')}` : `${s.scopeName} ${s.operator}`
+                ),
+              ];
+              if (scopes.length === 0) {
+                return <span className="text-gray-400 text-xs">All objects</span>;
+              }
+              return (
+                <div className="flex flex-wrap gap-1">
+                  {scopes.map((scope) => (
+                    <span key={scope} className="px-2 py-0.5 bg-yellow-50 text-yellow-800 rounded text-xs font-mono">
+                      {scope}
+                    </span>
+                  ))}
+                </div>
+              );
+            }},
             { key: 'age', header: 'Age', className: 'text-gray-600' },
           ]}
         />
@@ -9,6 +9,7 @@ import { useToast } from '../components/Toast';
 import { ConfirmDialog } from '../components/ConfirmDialog';
 import { ContainerCard } from '../components/ContainerCard';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface ReplicaSetsProps {
   namespace?: string;
@@ -33,7 +34,7 @@ function ReplicaSetDetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: replicasetDetails, isLoading: detailsLoading } = useQuery({
+  const { data: replicasetDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['replicaset-details', replicaset.namespace, replicaset.name],
     queryFn: () => api.workloads.getReplicaSet(replicaset.namespace, replicaset.name),
   });
@@ -67,6 +68,8 @@ function ReplicaSetDetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
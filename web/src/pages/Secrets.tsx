@@ -8,6 +8,7 @@ import { ActionMenu } from '../components/ActionMenu';
 import { useToast } from '../components/Toast';
 import { ConfirmDialog } from '../components/ConfirmDialog';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface SecretsProps {
   namespace?: string;
@@ -51,7 +52,7 @@ function SecretDetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: secretDetails, isLoading: detailsLoading } = useQuery({
+  const { data: secretDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['secret-details', secret.namespace, secret.name],
     queryFn: () => api.secrets.get(secret.namespace, secret.name),
   });
@@ -91,6 +92,8 @@ function SecretDetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
@@ -170,10 +173,11 @@ export function Secrets({ namespace, isConnected = true }: SecretsProps) {
   const [yamlSecret, setYamlSecret] = useState<SecretInfo | null>(null);
   const [deleteTarget, setDeleteTarget] = useState<SecretInfo | null>(null);
   const [selectedSecret, setSelectedSecret] = useState<SecretInfo | null>(null);
+  const [hideTokens, setHideTokens] = useState(true);
 
   const { data: secrets, isLoading, error } = useQuery({
-    queryKey: ['secrets', namespace],
-    queryFn: () => api.secrets.list(namespace),
+    queryKey: ['secrets', namespace, hideTokens],
+    queryFn: () => api.secrets.list(namespace, hideTokens ? '!service-account-token' : undefined),
     refetchInterval: isConnected ? 5000 : false,
     enabled: isConnected,
   });
@@ -205,13 +209,23 @@ export function Secrets({ namespace, isConnected = true }: SecretsProps) {
     <div>
       <div className="flex justify-between items-center mb-4">
         <h1 className="text-2xl font-bold">Secrets</h1>
-        <button
-          onClick={() => queryClient.invalidateQueries({ queryKey: ['secrets'] })}
-          className="flex items-center gap-2 px-3 py-2 text-sm bg-gray-100 hover:bg-gray-200 rounded"
-        >
-          <RefreshCw className="w-4 h-4" />
-          Refresh
-        </button>
+        <div className="flex items-center gap-4">
+          <label className="flex items-center gap-1 text-sm text-gray-600">
+            <input
+              type="checkbox"
+              checked={hideTokens}
+              onChange={(e) => setHideTokens(e.target.checked)}
+            />
+            Hide service account tokens
+          </label>
+          <button
+            onClick={() => queryClient.invalidateQueries({ queryKey: ['secrets'] })}
+            className="flex items-center gap-2 px-3 py-2 text-sm bg-gray-100 hover:bg-gray-200 rounded"
+          >
+            <RefreshCw className="w-4 h-4" />
+            Refresh
+          </button>
+        </div>
       </div>
 
       <div className="bg-white rounded-lg shadow overflow-hidden">
@@ -8,6 +8,7 @@ import { ActionMenu } from '../components/ActionMenu';
 import { useToast } from '../components/Toast';
 import { ConfirmDialog } from '../components/ConfirmDialog';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface ServicesProps {
   namespace?: string;
@@ -38,7 +39,7 @@ function ServiceDetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: serviceDetails, isLoading: detailsLoading } = useQuery({
+  const { data: serviceDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['service-details', service.namespace, service.name],
     queryFn: () => api.services.get(service.namespace, service.name),
   });
@@ -72,6 +73,8 @@ function ServiceDetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
@@ -131,8 +134,36 @@ function ServiceDetailsPanel({
           </div>
         )}
 
-        {/* Endpoints */}
-        {details.endpoints && details.endpoints.length > 0 && (
+        {/* Backing pods, when endpoints reference pods */}
+        {details.backingPods && details.backingPods.length > 0 && (
+          <div>
+            <h3 className="text-sm font-semibold text-gray-700 mb-2">Backing Pods ({details.backingPods.length})</h3>
+            <div className="space-y-1">
+              {details.backingPods.map((pod) => (
+                <div key={`${pod.name}-${pod.ip}`} className="flex items-center gap-2 text-sm">
+                  <span
+                    className={`w-2 h-2 rounded-full ${pod.serving ? 'bg-green-500' : 'bg-yellow-500'}`}
+                    title={pod.serving ? 'Receiving traffic' : 'Not ready, not receiving traffic'}
+                  />
+                  <span className="font-medium">{pod.name}</span>
+                  <span className="font-mono text-gray-500">{pod.ip}</span>
+                  {pod.missing ? (
+                    <span className="text-xs text-red-600">pod no longer exists</span>
+                  ) : (
+                    <span className="text-xs text-gray-500">
+                      {pod.status} · {pod.ready} ready
+                      {pod.restarts > 0 && <span className="text-yellow-700"> · {pod.restarts} restarts</span>}
+                    </span>
+                  )}
+                  {pod.nodeName && <span className="text-gray-400">({pod.nodeName})</span>}
+                </div>
+              ))}
+            </div>
+          </div>
+        )}
+
+        {/* Endpoints without a pod targetRef */}
+        {!details.backingPods?.length && details.endpoints && details.endpoints.length > 0 && (
           <div>
             <h3 className="text-sm font-semibold text-gray-700 mb-2">Endpoints ({details.endpoints.length})</h3>
             <div className="space-y-1">
@@ -9,6 +9,7 @@ import { useToast } from '../components/Toast';
 import { ConfirmDialog } from '../components/ConfirmDialog';
 import { ContainerCard } from '../components/ContainerCard';
 import { MetadataTabs } from '../components/MetadataTabs';
+import { LookupErrorNotice } from '../components/LookupErrorNotice';
 
 interface StatefulSetsProps {
   namespace?: string;
@@ -33,7 +34,7 @@ function StatefulSetDetailsPanel({
   onClose: () => void;
   onViewYaml: () => void;
 }) {
-  const { data: statefulsetDetails, isLoading: detailsLoading } = useQuery({
+  const { data: statefulsetDetails, isLoading: detailsLoading, error: detailsError } = useQuery({
     queryKey: ['statefulset-details', statefulset.namespace, statefulset.name],
     queryFn: () => api.workloads.getStatefulSet(statefulset.namespace, statefulset.name),
   });
@@ -67,6 +68,8 @@ function StatefulSetDetailsPanel({
       </div>
 
       <div className="flex-1 overflow-auto p-4 space-y-6">
+        <LookupErrorNotice error={detailsError} />
+
         {/* Status Overview */}
         <div className="grid grid-cols-2 gap-4">
           <div className="bg-gray-50 p-3 rounded">
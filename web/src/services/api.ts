@@ -1,5 +1,16 @@
 const API_BASE = '/api';
 
+// Thrown when a detail endpoint reports the resource as missing or not readable
+export class ResourceLookupError extends Error {
+  readonly forbidden: boolean;
+
+  constructor(message: string, forbidden: boolean) {
+    super(message);
+    this.name = 'ResourceLookupError';
+    this.forbidden = forbidden;
+  }
+}
+
 async function request<T>(endpoint: string, options?: RequestInit): Promise<T> {
   const response = await fetch(`${API_BASE}${endpoint}`, {
     headers: {
@@ -10,6 +21,19 @@ async function request<T>(endpoint: string, options?: RequestInit): Promise<T> {
 
   if (!response.ok) {
     const error = await response.text();
+    // Detail endpoints answer 404 and 403 with found: false or forbidden: true
+    // next to the API server's message
+    if (response.status === 404 || response.status === 403) {
+      let body: { message?: string; found?: boolean; forbidden?: boolean } | undefined;
+      try {
+        body = JSON.parse(error).error;
+      } catch {
+        // Not JSON, e.g. a disabled resource type
+      }
+      if (body && (body.found === false || body.forbidden === true)) {
+        throw new ResourceLookupError(body.message || response.statusText, body.forbidden === true);
+      }
+    }
     throw new Error(error || response.statusText);
   }
 
@@ -19,6 +43,7 @@ async function request<T>(endpoint: string, options?: RequestInit): Promise<T> {
   }
 
   const json = await response.json();
+
   return json.data;
 }
 
@@ -46,7 +71,44 @@ export interface PodInfo {
   ip: string;
   ports?: ContainerPort[];
   containers?: ContainerInfo[];
+  initContainers?: ContainerInfo[];
+  ephemeralContainers?: ContainerInfo[]; // Added by kubectl debug
   labels?: Record<string, string>;
+  conditions?: PodCondition[];
+  notReadyReason?: string; // e.g. "PodScheduled=False: 0/5 nodes are available"
+  nodeHealth?: PodNodeHealth; // Only set on the pod details response
+}
+
+export interface PodNodeHealth {
+  name: string;
+  ready: boolean;
+  unschedulable?: boolean;
+  conditions?: { type: string; status: string; message: string }[];
+  problems?: string[]; // e.g. "MemoryPressure=True: kubelet has insufficient memory available"
+  error?: string; // The node couldn't be read, e.g. no permission to get nodes
+}
+
+export interface PodCondition {
+  type: string;
+  status: string;
+  reason?: string;
+  message?: string;
+}
+
+export interface ExecResult {
+  stdout: string;
+  stderr: string;
+  exitCode: number;
+  truncated?: boolean;
+  sessionId?: string;
+}
+
+export interface DebugContainer {
+  name: string;
+  pod: string;
+  namespace: string;
+  image: string;
+  target?: string;
 }
 
 export interface ResourceUsage {
@@ -69,12 +131,14 @@ export interface ContainerPort {
 export interface ContainerInfo {
   name: string;
   image: string;
+  imageID?: string; // Digest of the image actually running
   ready: boolean;
   restartCount: number;
   state: string;
   ports?: ContainerPort[];
   resources?: ContainerResource;
   env?: EnvVar[];
+  envConflicts?: EnvConflict[];
 }
 
 export interface EnvVar {
@@ -83,6 +147,13 @@ export interface EnvVar {
   valueFrom?: string; // "configmap:name/key", "secret:name/key", "field:path"
 }
 
+// A name declared more than once in a container; the last declaration wins
+export interface EnvConflict {
+  name: string;
+  winner: string;
+  overridden: string[];
+}
+
 export interface DeploymentInfo {
   name: string;
   namespace: string;
@@ -100,6 +171,9 @@ export interface DeploymentInfo {
   containerDetails?: DeploymentContainer[];
   conditions?: DeploymentCondition[];
   runningContainers?: RunningContainer[];
+  // Pod template metadata; editing it triggers a rollout
+  templateLabels?: Record<string, string>;
+  templateAnnotations?: Record<string, string>;
 }
 
 export interface RunningContainer {
@@ -119,6 +193,7 @@ export interface DeploymentContainer {
   memory: DeploymentResourceUsage;
   ports?: DeploymentContainerPort[];
   env?: EnvVar[];
+  envConflicts?: EnvConflict[];
 }
 
 export interface DeploymentResourceUsage {
@@ -146,6 +221,18 @@ export interface DeploymentEvent {
   message: string;
   count: number;
   age: string;
+  object?: string;
+}
+
+export interface DeploymentRevision {
+  revision: number;
+  replicaSet: string;
+  images: string[];
+  changeCause?: string;
+  replicas: number;
+  current: boolean;
+  created: string;
+  age: string;
 }
 
 export interface ServiceInfo {
@@ -161,6 +248,18 @@ export interface ServiceInfo {
   sessionAffinity?: string;
   portDetails?: ServicePort[];
   endpoints?: ServiceEndpoint[];
+  backingPods?: BackingPod[];
+}
+
+export interface BackingPod {
+  name: string;
+  ip: string;
+  nodeName?: string;
+  serving: boolean;
+  status?: string;
+  ready?: string;
+  restarts: number;
+  missing?: boolean;
 }
 
 export interface ServicePort {
@@ -204,6 +303,23 @@ export interface ConfigMapEvent {
   age: string;
 }
 
+export interface ConfigMapVersions {
+  baseName: string;
+  versions: ConfigMapVersion[]; // Newest first
+}
+
+export interface ConfigMapVersion {
+  name: string;
+  suffix?: string; // Content hash, e.g. from kustomize's configMapGenerator
+  keys: number;
+  immutable: boolean;
+  usedBy: number; // Pods referencing it; 0 usually means a stale version
+  requested: boolean;
+  newest: boolean;
+  created: string;
+  age: string;
+}
+
 export interface SecretInfo {
   name: string;
   namespace: string;
@@ -357,10 +473,19 @@ export interface NodeInfo {
   cpu: NodeResource;
   memory: NodeResource;
   pods: NodeResource;
+  storage: NodeStorage;
   labels: Record<string, string>;
   conditions: NodeCondition[];
 }
 
+export interface NodeStorage {
+  capacity: number;    // ephemeral-storage in bytes
+  allocatable: number; // ephemeral-storage available to pods in bytes
+  requested: number;   // ephemeral-storage requested by pods in bytes
+  diskPressure: boolean;
+  pressureMessage?: string;
+}
+
 export interface NodeCondition {
   type: string;
   status: string;
@@ -578,6 +703,20 @@ export interface HPACondition {
   message: string;
 }
 
+export interface HPAActivity {
+  name: string;
+  namespace: string;
+  reference: string;
+  minReplicas: number;
+  maxReplicas: number;
+  currentReplicas: number;
+  desiredReplicas: number;
+  atMax: boolean;
+  atMin: boolean;
+  limitingCondition?: HPACondition;
+  lastScaleTime?: string;
+}
+
 export interface HPAScalingRules {
   stabilizationWindowSeconds?: number;
   selectPolicy?: string;
@@ -654,6 +793,14 @@ export interface ResourceQuotaInfo {
   hard: Record<string, string>;
   used: Record<string, string>;
   age: string;
+  scopes?: string[];
+  scopeSelector?: QuotaScopeSelector[];
+}
+
+export interface QuotaScopeSelector {
+  scopeName: string;
+  operator: string;
+  values?: string[];
 }
 
 export interface LimitRangeInfo {
@@ -680,13 +827,15 @@ export const api = {
 
   pods: {
     list: (namespace?: string) =>
-      request<PodInfo[]>(`/pods${namespace ? `?namespace=${namespace}` : ''}`),
+      request<PodInfo[]>(`/pods?namespace=${namespace || '*'}`),
     get: (namespace: string, name: string) =>
       request<PodInfo>(`/pods/${namespace}/${name}`),
-    logs: (namespace: string, name: string, container?: string, tail?: number) => {
+    logs: (namespace: string, name: string, container?: string, tail?: number, previous?: boolean, timestamps?: boolean) => {
       const params = new URLSearchParams();
       if (container) params.set('container', container);
       if (tail) params.set('tail', tail.toString());
+      if (previous) params.set('previous', 'true');
+      if (timestamps) params.set('timestamps', 'true');
       const query = params.toString();
       return request<{ logs: string }>(`/pods/${namespace}/${name}/logs${query ? `?${query}` : ''}`);
     },
@@ -694,6 +843,18 @@ export const api = {
       request<PodEvent[]>(`/pods/${namespace}/${name}/events`),
     delete: (namespace: string, name: string) =>
       request<{ message: string }>(`/pods/${namespace}/${name}`, { method: 'DELETE' }),
+    // Runs a command without a TTY; a non-zero exitCode is a normal result
+    execOnce: (namespace: string, name: string, command: string[], container?: string) =>
+      request<ExecResult>(`/pods/${namespace}/${name}/exec-once`, {
+        method: 'POST',
+        body: JSON.stringify({ container, command }),
+      }),
+    // Adds an ephemeral debug container and resolves once it's running
+    debug: (namespace: string, name: string, image?: string, target?: string) =>
+      request<DebugContainer>(`/pods/${namespace}/${name}/debug`, {
+        method: 'POST',
+        body: JSON.stringify({ image, target }),
+      }),
   },
 
   portForward: {
@@ -713,11 +874,13 @@ export const api = {
 
   deployments: {
     list: (namespace?: string) =>
-      request<DeploymentInfo[]>(`/deployments${namespace ? `?namespace=${namespace}` : ''}`),
+      request<DeploymentInfo[]>(`/deployments?namespace=${namespace || '*'}`),
     get: (namespace: string, name: string) =>
       request<DeploymentInfo>(`/deployments/${namespace}/${name}`),
-    events: (namespace: string, name: string) =>
-      request<DeploymentEvent[]>(`/deployments/${namespace}/${name}/events`),
+    events: (namespace: string, name: string, includeChildren = false) =>
+      request<DeploymentEvent[]>(
+        `/deployments/${namespace}/${name}/events${includeChildren ? '?includeChildren=true' : ''}`
+      ),
     scale: (namespace: string, name: string, replicas: number) =>
       request<{ message: string; replicas: number }>(`/deployments/${namespace}/${name}/scale`, {
         method: 'PATCH',
@@ -727,13 +890,28 @@ export const api = {
       request<{ message: string }>(`/deployments/${namespace}/${name}/restart`, {
         method: 'POST',
       }),
+    setImage: (namespace: string, name: string, container: string, image: string) =>
+      request<{ message: string; container: string; image: string; previousImage: string }>(
+        `/deployments/${namespace}/${name}/image`,
+        {
+          method: 'PATCH',
+          body: JSON.stringify({ container, image }),
+        }
+      ),
+    history: (namespace: string, name: string) =>
+      request<DeploymentRevision[]>(`/deployments/${namespace}/${name}/history`),
+    rollback: (namespace: string, name: string, revision: number) =>
+      request<{ message: string; revision: string }>(`/deployments/${namespace}/${name}/rollback`, {
+        method: 'POST',
+        body: JSON.stringify({ revision }),
+      }),
     delete: (namespace: string, name: string) =>
       request<{ message: string }>(`/deployments/${namespace}/${name}`, { method: 'DELETE' }),
   },
 
   services: {
     list: (namespace?: string) =>
-      request<ServiceInfo[]>(`/services${namespace ? `?namespace=${namespace}` : ''}`),
+      request<ServiceInfo[]>(`/services?namespace=${namespace || '*'}`),
     get: (namespace: string, name: string) =>
       request<ServiceInfo>(`/services/${namespace}/${name}`),
     events: (namespace: string, name: string) =>
@@ -744,18 +922,23 @@ export const api = {
 
   configmaps: {
     list: (namespace?: string) =>
-      request<ConfigMapInfo[]>(`/configmaps${namespace ? `?namespace=${namespace}` : ''}`),
+      request<ConfigMapInfo[]>(`/configmaps?namespace=${namespace || '*'}`),
     get: (namespace: string, name: string) =>
       request<ConfigMapInfo>(`/configmaps/${namespace}/${name}`),
     events: (namespace: string, name: string) =>
       request<ConfigMapEvent[]>(`/configmaps/${namespace}/${name}/events`),
+    relatedVersions: (namespace: string, name: string) =>
+      request<ConfigMapVersions>(`/configmaps/${namespace}/${name}/related-versions`),
     delete: (namespace: string, name: string) =>
       request<{ message: string }>(`/configmaps/${namespace}/${name}`, { method: 'DELETE' }),
   },
 
   secrets: {
-    list: (namespace?: string) =>
-      request<SecretInfo[]>(`/secrets${namespace ? `?namespace=${namespace}` : ''}`),
+    // type filters server-side, e.g. 'Opaque' or '!service-account-token'
+    list: (namespace?: string, type?: string) =>
+      request<SecretInfo[]>(
+        `/secrets?namespace=${namespace || '*'}${type ? `&type=${encodeURIComponent(type)}` : ''}`
+      ),
     get: (namespace: string, name: string) =>
       request<SecretInfo>(`/secrets/${namespace}/${name}`),
     events: (namespace: string, name: string) =>
@@ -766,19 +949,21 @@ export const api = {
 
   jobs: {
     list: (namespace?: string) =>
-      request<JobInfo[]>(`/jobs${namespace ? `?namespace=${namespace}` : ''}`),
+      request<JobInfo[]>(`/jobs?namespace=${namespace || '*'}`),
     get: (namespace: string, name: string) =>
       request<JobInfo>(`/jobs/${namespace}/${name}`),
     events: (namespace: string, name: string) =>
       request<JobEvent[]>(`/jobs/${namespace}/${name}/events`),
     listCronJobs: (namespace?: string) =>
-      request<CronJobInfo[]>(`/cronjobs${namespace ? `?namespace=${namespace}` : ''}`),
+      request<CronJobInfo[]>(`/cronjobs?namespace=${namespace || '*'}`),
     getCronJob: (namespace: string, name: string) =>
       request<CronJobInfo>(`/cronjobs/${namespace}/${name}`),
     cronJobEvents: (namespace: string, name: string) =>
       request<CronJobEvent[]>(`/cronjobs/${namespace}/${name}/events`),
     cronJobJobs: (namespace: string, name: string) =>
       request<JobInfo[]>(`/cronjobs/${namespace}/${name}/jobs`),
+    triggerCronJob: (namespace: string, name: string) =>
+      request<{ message: string; job: string }>(`/cronjobs/${namespace}/${name}/trigger`, { method: 'POST' }),
     delete: (namespace: string, name: string) =>
       request<{ message: string }>(`/jobs/${namespace}/${name}`, { method: 'DELETE' }),
     deleteCronJob: (namespace: string, name: string) =>
@@ -788,7 +973,7 @@ export const api = {
   storage: {
     listPVs: () => request<PVInfo[]>('/pvs'),
     listPVCs: (namespace?: string) =>
-      request<PVCInfo[]>(`/pvcs${namespace ? `?namespace=${namespace}` : ''}`),
+      request<PVCInfo[]>(`/pvcs?namespace=${namespace || '*'}`),
   },
 
   yaml: {
@@ -796,6 +981,10 @@ export const api = {
       request<{ yaml: string; canEdit: boolean }>(`/yaml/${type}/${namespace}/${name}`),
     getClusterScoped: (type: string, name: string) =>
       request<{ yaml: string; canEdit: boolean }>(`/yaml/${type}/${name}`),
+    getJSON: (type: string, namespace: string | undefined, name: string) =>
+      request<{ json: string; canEdit: boolean }>(
+        namespace ? `/yaml/${type}/${namespace}/${name}?format=json` : `/yaml/${type}/${name}?format=json`
+      ),
     update: (type: string, namespace: string, name: string, yaml: string) =>
       request<void>(`/yaml/${type}/${namespace}/${name}`, {
         method: 'PUT',
@@ -811,7 +1000,7 @@ export const api = {
   crds: {
     list: () => request<CRDInfo[]>('/crds'),
     listInstances: (group: string, version: string, resource: string, namespace?: string) =>
-      request<CRInfo[]>(`/crds/${group}/${version}/${resource}${namespace ? `?namespace=${namespace}` : ''}`),
+      request<CRInfo[]>(`/crds/${group}/${version}/${resource}?namespace=${namespace || '*'}`),
     getInstance: (group: string, version: string, resource: string, namespace: string, name: string) =>
       request<Record<string, unknown>>(`/crds/${group}/${version}/${resource}/${namespace}/${name}`),
   },
@@ -822,19 +1011,19 @@ export const api = {
 
   workloads: {
     listDaemonSets: (namespace?: string) =>
-      request<DaemonSetInfo[]>(`/daemonsets${namespace ? `?namespace=${namespace}` : ''}`),
+      request<DaemonSetInfo[]>(`/daemonsets?namespace=${namespace || '*'}`),
     getDaemonSet: (namespace: string, name: string) =>
       request<DaemonSetInfo>(`/daemonsets/${namespace}/${name}`),
     daemonSetEvents: (namespace: string, name: string) =>
       request<DaemonSetEvent[]>(`/daemonsets/${namespace}/${name}/events`),
     listStatefulSets: (namespace?: string) =>
-      request<StatefulSetInfo[]>(`/statefulsets${namespace ? `?namespace=${namespace}` : ''}`),
+      request<StatefulSetInfo[]>(`/statefulsets?namespace=${namespace || '*'}`),
     getStatefulSet: (namespace: string, name: string) =>
       request<StatefulSetInfo>(`/statefulsets/${namespace}/${name}`),
     statefulSetEvents: (namespace: string, name: string) =>
       request<StatefulSetEvent[]>(`/statefulsets/${namespace}/${name}/events`),
     listReplicaSets: (namespace?: string) =>
-      request<ReplicaSetInfo[]>(`/replicasets${namespace ? `?namespace=${namespace}` : ''}`),
+      request<ReplicaSetInfo[]>(`/replicasets?namespace=${namespace || '*'}`),
     getReplicaSet: (namespace: string, name: string) =>
       request<ReplicaSetInfo>(`/replicasets/${namespace}/${name}`),
     replicaSetEvents: (namespace: string, name: string) =>
@@ -849,11 +1038,11 @@ export const api = {
 
   network: {
     listIngresses: (namespace?: string) =>
-      request<IngressInfo[]>(`/ingresses${namespace ? `?namespace=${namespace}` : ''}`),
+      request<IngressInfo[]>(`/ingresses?namespace=${namespace || '*'}`),
     listEndpoints: (namespace?: string) =>
-      request<EndpointInfo[]>(`/endpoints${namespace ? `?namespace=${namespace}` : ''}`),
+      request<EndpointInfo[]>(`/endpoints?namespace=${namespace || '*'}`),
     listNetworkPolicies: (namespace?: string) =>
-      request<NetworkPolicyInfo[]>(`/networkpolicies${namespace ? `?namespace=${namespace}` : ''}`),
+      request<NetworkPolicyInfo[]>(`/networkpolicies?namespace=${namespace || '*'}`),
     deleteIngress: (namespace: string, name: string) =>
       request<{ message: string }>(`/ingresses/${namespace}/${name}`, { method: 'DELETE' }),
     deleteNetworkPolicy: (namespace: string, name: string) =>
@@ -862,18 +1051,31 @@ export const api = {
 
   hpas: {
     list: (namespace?: string) =>
-      request<HPAInfo[]>(`/hpas${namespace ? `?namespace=${namespace}` : ''}`),
+      request<HPAInfo[]>(`/hpas?namespace=${namespace || '*'}`),
+    activity: (namespace?: string) =>
+      request<HPAActivity[]>(`/hpas/activity?namespace=${namespace || '*'}`),
     get: (namespace: string, name: string) =>
       request<HPAInfo>(`/hpas/${namespace}/${name}`),
     events: (namespace: string, name: string) =>
       request<HPAEvent[]>(`/hpas/${namespace}/${name}/events`),
+    // yaml may also be JSON; its metadata.namespace wins over the namespace param
+    create: (yaml: string, namespace?: string) =>
+      request<{ message: string; name: string; namespace: string }>(
+        namespace ? `/hpas?namespace=${namespace}` : '/hpas',
+        {
+          method: 'POST',
+          body: JSON.stringify({ yaml }),
+        }
+      ),
+    delete: (namespace: string, name: string) =>
+      request<{ message: string }>(`/hpas/${namespace}/${name}`, { method: 'DELETE' }),
   },
 
   events: {
     list: (namespace?: string) =>
-      request<EventInfo[]>(`/events${namespace ? `?namespace=${namespace}` : ''}`),
+      request<EventInfo[]>(`/events?namespace=${namespace || '*'}`),
     listWarnings: (namespace?: string) =>
-      request<WarningEventGroup[]>(`/events/warnings${namespace ? `?namespace=${namespace}` : ''}`),
+      request<WarningEventGroup[]>(`/events/warnings?namespace=${namespace || '*'}`),
   },
 
   storageClasses: {
@@ -882,20 +1084,20 @@ export const api = {
 
   serviceAccounts: {
     list: (namespace?: string) =>
-      request<ServiceAccountInfo[]>(`/serviceaccounts${namespace ? `?namespace=${namespace}` : ''}`),
+      request<ServiceAccountInfo[]>(`/serviceaccounts?namespace=${namespace || '*'}`),
   },
 
   quotas: {
     listResourceQuotas: (namespace?: string) =>
-      request<ResourceQuotaInfo[]>(`/resourcequotas${namespace ? `?namespace=${namespace}` : ''}`),
+      request<ResourceQuotaInfo[]>(`/resourcequotas?namespace=${namespace || '*'}`),
     listLimitRanges: (namespace?: string) =>
-      request<LimitRangeInfo[]>(`/limitranges${namespace ? `?namespace=${namespace}` : ''}`),
+      request<LimitRangeInfo[]>(`/limitranges?namespace=${namespace || '*'}`),
   },
 
   search: {
     query: (q: string, namespace?: string) => {
       const params = new URLSearchParams({ q });
-      if (namespace) params.set('namespace', namespace);
+      params.set('namespace', namespace || '*');
       return request<SearchResult[]>(`/search?${params}`);
     },
   },